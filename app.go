@@ -2,25 +2,339 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/wailsapp/wails/v2/pkg/runtime"
+
+	"workoff-timer/internal/attendance"
 	"workoff-timer/internal/festival"
+	"workoff-timer/internal/overtime"
+	"workoff-timer/internal/schedule"
 )
 
 // App struct
 type App struct {
-	ctx context.Context
+	ctx         context.Context
+	mu          sync.RWMutex
+	clock       festival.Clock
+	calendar    *festival.HolidayCalendar
+	schedule    *schedule.Schedule
+	showSeasons bool
+	events      []festival.PersonalEvent
+	datasetErr  error
+	scheduleErr error
+	// arrival 今天（arrivalDate）的到岗时刻，用于弹性工作时间，零值表示已手动清除，受mu保护
+	// arrivalAuto 为true表示是查询状态时自动记录的，不如上班打卡可靠
+	arrival     time.Time
+	arrivalDate string
+	arrivalAuto bool
+	// arrivalPath 到岗记录文件路径，为空时不保存；arrivalErr 最近一次读写该文件的错误，受mu保护
+	arrivalPath string
+	arrivalErr  error
+	// attendance 打卡记录，打开失败时为nil，错误见attendanceErr
+	attendance    *attendance.Store
+	attendanceErr error
 }
 
 // NewApp creates a new App application struct
 func NewApp() *App {
-	return &App{}
+	return newApp(festival.SystemClock{})
+}
+
+// newApp 使用指定时钟创建App，测试时可传入固定时钟
+func newApp(clock festival.Clock) *App {
+	a := &App{
+		clock:    clock,
+		calendar: festival.NewHolidayCalendar(),
+	}
+	a.schedule = a.newSchedule()
+	return a
+}
+
+// newSchedule 创建作息：默认周一至周五 09:00-19:00，12:00-13:30午休，法定节假日放假、调休日上班
+// opts 来自 schedule.json，可以改每天的时段或改为轮班
+func (a *App) newSchedule(opts ...schedule.Option) *schedule.Schedule {
+	defaults := []schedule.Option{schedule.WithWindow(officeWindow), schedule.WithWorkdays(a.isWorkday), schedule.WithArrivals(a.arrivalOn)}
+	return schedule.NewSchedule(append(defaults, opts...)...)
+}
+
+// arrivalOn 获取某天的到岗时刻：手动设置的优先，其次是当天第一次上班打卡，最后是自动记录的
+func (a *App) arrivalOn(day time.Time) (time.Time, bool) {
+	a.mu.RLock()
+	arrival, auto := a.arrival, a.arrivalAuto
+	a.mu.RUnlock()
+	from, to := attendance.PeriodDay.Range(day)
+	recorded := !arrival.IsZero() && !arrival.Before(from) && arrival.Before(to)
+	if recorded && !auto {
+		return arrival, true
+	}
+	if a.attendance != nil {
+		if sessions := a.attendance.Sessions(from, to); len(sessions) > 0 {
+			return sessions[0].GetIn().GetTime(), true
+		}
+	}
+	if recorded {
+		return arrival, true
+	}
+	return time.Time{}, false
+}
+
+// officeWindow 默认工作时段 09:00-19:00，含不计薪的午休
+var officeWindow = mustWindow(schedule.DefaultWindow.WithBreaks(schedule.LunchBreak))
+
+// mustWindow 工作时段有误时panic，仅用于内置作息
+func mustWindow(w schedule.Window, err error) schedule.Window {
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+// isWorkday 按节假日日历判断某天是否上班
+func (a *App) isWorkday(day time.Time) bool {
+	return a.calendar.IsWorkday(festival.NewSolarDayFromTime(day.In(festival.CalendarLocation())))
 }
 
 // startup is called when the app starts. The context is saved
 // so we can call the runtime methods
 func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
+	var eventsErr error
+	a.events, eventsErr = loadPersonalEvents()
+	a.datasetErr = errors.Join(loadFestivalDataset(), eventsErr)
+	opts, err := loadScheduleConfig()
+	if err == nil {
+		a.schedule = a.newSchedule(opts...)
+	}
+	a.scheduleErr = err
+	a.attendance, a.attendanceErr = openAttendance()
+	if dir, err := configDir(); err == nil {
+		a.loadArrival(filepath.Join(dir, "arrival.json"))
+	}
+	a.recordArrival()
+}
+
+// shutdown 退出时关闭打卡记录
+func (a *App) shutdown(ctx context.Context) {
+	if a.attendance != nil {
+		a.attendance.Close()
+	}
+}
+
+// openAttendance 打开配置目录下的打卡记录 attendance.jsonl，不存在时创建
+func openAttendance() (*attendance.Store, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return attendance.Open(filepath.Join(dir, "attendance.jsonl"))
+}
+
+// arrivalRecord 到岗记录文件（arrival.json）的内容，只保存最近一天，Arrival为空表示当天已手动清除
+type arrivalRecord struct {
+	Date    string     `json:"date"`
+	Arrival *time.Time `json:"arrival,omitempty"`
+	// Auto 是否为自动记录的
+	Auto bool `json:"auto,omitempty"`
+}
+
+// loadArrival 读取到岗记录文件，之后记录、设置到岗时刻时都写入该文件；文件不存在时不算错误
+func (a *App) loadArrival(path string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.arrivalPath = path
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	var r arrivalRecord
+	if err == nil {
+		err = json.Unmarshal(data, &r)
+	}
+	if err != nil {
+		a.arrivalErr = fmt.Errorf("%s: %w", path, err)
+		return a.arrivalErr
+	}
+	a.arrivalDate, a.arrival, a.arrivalAuto = r.Date, time.Time{}, r.Auto
+	if r.Arrival != nil {
+		a.arrival = r.Arrival.In(festival.CalendarLocation())
+	}
+	return nil
+}
+
+// setArrival 设置date那天的到岗时刻并保存，auto表示是自动记录的，调用时需持有mu
+func (a *App) setArrival(date string, arrival time.Time, auto bool) error {
+	a.arrivalDate, a.arrival, a.arrivalAuto = date, arrival, auto
+	if a.arrivalPath == "" {
+		return nil
+	}
+	a.arrivalErr = a.saveArrival(date, arrival, auto)
+	return a.arrivalErr
+}
+
+// saveArrival 写入到岗记录文件
+func (a *App) saveArrival(date string, arrival time.Time, auto bool) error {
+	r := arrivalRecord{Date: date, Auto: auto}
+	if !arrival.IsZero() {
+		r.Arrival = &arrival
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	// 先写临时文件再改名，写到一半退出时不会留下损坏的记录
+	if err := os.MkdirAll(filepath.Dir(a.arrivalPath), 0o755); err != nil {
+		return err
+	}
+	tmp := a.arrivalPath + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, a.arrivalPath)
+}
+
+// recordArrival 弹性工作时间下，每天在到岗时段内第一次启动或查询状态时记为到岗；当天已有记录（含手动清除、上班打卡）时不变
+// 不在到岗时段内时不记录（如开着过夜，零点后的查询不算到岗），需手动设置（见 SetArrival）或上班打卡
+// 自动记录的到岗时刻不如上班打卡可靠，见 arrivalOn；保存失败时错误见 GetFlexStatus 的提醒
+func (a *App) recordArrival() {
+	now := a.now().In(festival.CalendarLocation())
+	date := now.Format(time.DateOnly)
+	a.mu.RLock()
+	recorded := a.arrivalDate == date
+	a.mu.RUnlock()
+	if recorded {
+		return
+	}
+	s := a.schedule.GetFlexStatus(now)
+	if s == nil || s.IsRecorded() || now.After(s.GetArrival()) {
+		return
+	}
+	earliest := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Add(a.schedule.GetFlex().GetEarliest())
+	if now.Before(earliest) {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.arrivalDate == date {
+		return
+	}
+	a.setArrival(date, now, true)
+}
+
+// configDir 获取配置目录，如 ~/.config/workoff-timer
+func configDir() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "workoff-timer"), nil
+}
+
+// openConfigFile 打开配置目录下的文件，文件不存在时返回nil
+func openConfigFile(name string) (*os.File, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(filepath.Join(dir, name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return f, err
+}
+
+// loadFestivalDataset 读取配置目录下的 festivals.json，合并到内置节日之后
+// 文件不存在时使用内置节日，文件有误时同样回退到内置节日并返回错误
+func loadFestivalDataset() error {
+	f, err := openConfigFile("festivals.json")
+	if f == nil || err != nil {
+		return err
+	}
+	defer f.Close()
+	custom, err := festival.LoadDataset(f.Name(), f)
+	if err != nil {
+		return err
+	}
+	festival.SetDataset(festival.DefaultDataset().Merge(custom))
+	return nil
+}
+
+// loadPersonalEvents 读取配置目录下的 events.json（生日、纪念日），文件不存在时返回空
+func loadPersonalEvents() ([]festival.PersonalEvent, error) {
+	f, err := openConfigFile("events.json")
+	if f == nil || err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return festival.LoadPersonalEvents(f.Name(), f)
+}
+
+// loadScheduleConfig 读取配置目录下的 schedule.json（每天的工作时段、轮班），文件不存在时使用默认作息
+func loadScheduleConfig() ([]schedule.Option, error) {
+	f, err := openConfigFile("schedule.json")
+	if f == nil || err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return schedule.LoadConfig(f.Name(), f, festival.CalendarLocation())
+}
+
+// GetScheduleError 获取作息文件（schedule.json）的错误信息，有误时使用默认作息，没有错误时返回空字符串
+func (a *App) GetScheduleError() string {
+	if a.scheduleErr == nil {
+		return ""
+	}
+	return a.scheduleErr.Error()
+}
+
+// GetFestivalDatasetError 获取自定义节日文件（festivals.json、events.json）的错误信息，没有错误时返回空字符串
+func (a *App) GetFestivalDatasetError() string {
+	if a.datasetErr == nil {
+		return ""
+	}
+	return a.datasetErr.Error()
+}
+
+// now 获取当前时刻，模拟时间时为模拟的时刻
+func (a *App) now() time.Time {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.clock.Now()
+}
+
+// nowDay 获取当前时刻所在的公历日（含时分秒，北京时间）
+func (a *App) nowDay() festival.SolarDay {
+	return festival.NewSolarDayFromTime(a.now().In(festival.CalendarLocation()))
+}
+
+// today 获取今天（仅日期，不含时分秒）
+func (a *App) today() festival.SolarDay {
+	t := a.nowDay()
+	d, _ := festival.NewSolarDay(t.GetYear(), t.GetMonth(), t.GetDay())
+	return d
+}
+
+// festivalOptions 根据设置生成节日查询选项
+func (a *App) festivalOptions() []festival.FestivalOption {
+	opts := []festival.FestivalOption{festival.WithPersonalEvents(a.events...), festival.WithHolidayCalendar(a.calendar)}
+	a.mu.RLock()
+	showSeasons := a.showSeasons
+	a.mu.RUnlock()
+	if showSeasons {
+		opts = append(opts, festival.WithSeasons())
+	}
+	return opts
 }
 
 // Greet returns a greeting for the given name
@@ -30,16 +344,20 @@ func (a *App) Greet(name string) string {
 
 // FestivalInfo 节日信息结构（返回给前端）
 type FestivalInfo struct {
-	Name string `json:"name"`
-	Days int    `json:"days"`
-	Type string `json:"type"`
+	Name  string   `json:"name"`
+	Names []string `json:"names"`
+	Days  int      `json:"days"`
+	Type  string   `json:"type"`
+	// HolidayDays 放假天数，不放假为0
+	HolidayDays int `json:"holidayDays"`
 }
 
-// GetNextFestival 获取下一个节日
+// GetNextFestival 获取下一个节日，同一天的多个节日合并显示，如“中秋节 · 国庆节”
 func (a *App) GetNextFestival() *FestivalInfo {
 	// 从今天开始查找60天内最近的节日
-	f := festival.Today().GetNearestFestival(60)
-	if f == nil {
+	today := a.today()
+	festivals := today.GetNearestFestivals(60, a.festivalOptions()...)
+	if len(festivals) == 0 {
 		return &FestivalInfo{
 			Name: "无",
 			Days: 0,
@@ -47,13 +365,659 @@ func (a *App) GetNextFestival() *FestivalInfo {
 		}
 	}
 
-	// 计算距离天数
-	today := festival.Today()
-	days := int(f.SolarDay.GetJulianDay().Subtract(today.GetJulianDay()))
+	// 计算距离天数（只比较日期）
+	f := festivals[0]
+	days := f.DaysUntil(today)
+
+	names := make([]string, 0, len(festivals))
+	for _, f := range festivals {
+		names = append(names, f.Name)
+	}
+	info := &FestivalInfo{
+		Name:  strings.Join(names, " · "),
+		Names: names,
+		Days:  days,
+		Type:  f.Type.String(),
+	}
+	for _, f := range festivals {
+		if f.Holiday != nil {
+			info.HolidayDays = f.GetDayCount()
+			break
+		}
+	}
+	return info
+}
+
+// DayInfo 当天工作日信息结构（返回给前端）
+type DayInfo struct {
+	Date        string `json:"date"`
+	Workday     bool   `json:"workday"`
+	Holiday     bool   `json:"holiday"`
+	HolidayName string `json:"holidayName"`
+	Makeup      bool   `json:"makeup"`
+}
+
+// GetDayInfo 获取今天的工作日信息（考虑法定节假日和调休）
+func (a *App) GetDayInfo() *DayInfo {
+	today := a.today()
+	info := &DayInfo{
+		Date:    today.String(),
+		Workday: a.calendar.IsWorkday(today),
+		Makeup:  a.calendar.IsMakeupWorkday(today),
+	}
+	if h := a.calendar.GetHoliday(today); h != nil {
+		info.Holiday = true
+		info.HolidayName = h.GetName()
+	}
+	return info
+}
+
+// HolidayStatusInfo 放假状态信息结构（返回给前端）
+type HolidayStatusInfo struct {
+	InProgress     bool   `json:"inProgress"`
+	Name           string `json:"name"`
+	Day            int    `json:"day"`
+	Days           int    `json:"days"`
+	BackToWork     string `json:"backToWork"`
+	BackToWorkDays int    `json:"backToWorkDays"`
+}
+
+// GetHolidayStatus 获取今天的放假状态，如“国庆节 第3天/共8天 6天后上班”，不在放假期间InProgress为false
+func (a *App) GetHolidayStatus() *HolidayStatusInfo {
+	s := a.calendar.GetHolidayStatus(a.today())
+	if s == nil {
+		return &HolidayStatusInfo{}
+	}
+	return &HolidayStatusInfo{
+		InProgress:     true,
+		Name:           s.GetHoliday().GetName(),
+		Day:            s.GetDayIndex(),
+		Days:           s.GetDayCount(),
+		BackToWork:     s.GetBackToWorkDay().String(),
+		BackToWorkDays: s.GetDaysUntilBackToWork(),
+	}
+}
+
+// GetBackToWorkCountdown 收假倒计时：距离上班还有几天，不在放假期间返回0
+func (a *App) GetBackToWorkCountdown() int {
+	if s := a.calendar.GetHolidayStatus(a.today()); s != nil {
+		return s.GetDaysUntilBackToWork()
+	}
+	return 0
+}
+
+// WorkStatusInfo 工作状态信息结构（返回给前端），时刻均为Unix毫秒
+type WorkStatusInfo struct {
+	Phase     string `json:"phase"`
+	PhaseName string `json:"phaseName"`
+	Window    string `json:"window"`
+	// Shift 当前或刚结束的班次名，如“夜班”，未命名时为空
+	Shift   string `json:"shift"`
+	StartAt int64  `json:"startAt"`
+	EndAt   int64  `json:"endAt"`
+	NextAt  int64  `json:"nextAt"`
+	// Seconds 距离下一次状态变化（上下班、开始或结束休息）的秒数
+	Seconds  int64   `json:"seconds"`
+	Progress float64 `json:"progress"`
+	// Break 当前所处的休息，如“午休”；NextBreak 上班中接下来的休息
+	Break     string `json:"break"`
+	NextBreak string `json:"nextBreak"`
+	// Segments 当天计薪的各段工作时间（扣除不计薪的休息），前端据此计算收入
+	Segments []WorkSegmentInfo `json:"segments"`
+	// NextShift 下一个班次名及其上班时刻，一年内没有班次时NextShiftAt为0
+	NextShift   string `json:"nextShift"`
+	NextShiftAt int64  `json:"nextShiftAt"`
+}
+
+// WorkSegmentInfo 一段计薪的工作时间，时刻为Unix毫秒
+type WorkSegmentInfo struct {
+	StartAt int64 `json:"startAt"`
+	EndAt   int64 `json:"endAt"`
+}
+
+// workPhaseKeys 工作阶段在前端的标识
+var workPhaseKeys = map[schedule.Phase]string{
+	schedule.PhaseBeforeWork: "before",
+	schedule.PhaseWorking:    "working",
+	schedule.PhaseAfterWork:  "after",
+	schedule.PhaseDayOff:     "off",
+	schedule.PhaseBreak:      "break",
+}
+
+// GetWorkStatus 获取当前的工作状态：未上班、上班中、已下班或休息日，距离下一次上下班的时间及当天工作进度
+func (a *App) GetWorkStatus() *WorkStatusInfo {
+	a.recordArrival()
+	now := a.now().In(festival.CalendarLocation())
+	s := a.schedule.GetStatus(now)
+	info := &WorkStatusInfo{
+		Phase:     workPhaseKeys[s.GetPhase()],
+		PhaseName: s.GetPhase().String(),
+		Seconds:   int64(s.GetTimeToNext() / time.Second),
+		Progress:  s.GetProgress(),
+	}
+	// 跨零点的夜班按上班那天计算
+	if w := s.GetWindow(); !w.IsZero() {
+		info.Window = w.String()
+		info.Shift = w.GetName()
+		info.StartAt = s.GetStart().UnixMilli()
+		info.EndAt = s.GetEnd().UnixMilli()
+		for _, seg := range w.GetPaidSegments() {
+			start, end := seg.On(s.GetStart())
+			info.Segments = append(info.Segments, WorkSegmentInfo{StartAt: start.UnixMilli(), EndAt: end.UnixMilli()})
+		}
+	}
+	if b := s.GetBreak(); b != nil {
+		info.Break = b.GetName()
+	}
+	if b := s.GetNextBreak(); b != nil {
+		info.NextBreak = b.GetName()
+	}
+	if !s.GetNext().IsZero() {
+		info.NextAt = s.GetNext().UnixMilli()
+	}
+	if w, start, ok := a.schedule.GetNextShift(now); ok {
+		info.NextShift = w.GetName()
+		info.NextShiftAt = start.UnixMilli()
+	}
+	return info
+}
+
+// FlexStatusInfo 弹性工作时间信息结构（返回给前端），时刻均为Unix毫秒
+type FlexStatusInfo struct {
+	// Arrival 到岗时刻，如“09:12”，没有记录时为空
+	Arrival string `json:"arrival"`
+	Late    bool   `json:"late"`
+	LeaveAt int64  `json:"leaveAt"`
+	Leave   string `json:"leave"`
+	// Balance 现在下班比应下班时刻早（负数）或晚（正数）的分钟数
+	Balance int `json:"balance"`
+	// Warning 提醒，如“未记录到岗时间”、“迟到”、“还差72分钟满9小时”
+	Warning string `json:"warning"`
+}
+
+// GetFlexStatus 获取今天的弹性工作状态：到岗时刻、应下班时刻、早退差额，不是弹性工作时间或今天不上班时返回nil
+func (a *App) GetFlexStatus() *FlexStatusInfo {
+	a.recordArrival()
+	now := a.now().In(festival.CalendarLocation())
+	s := a.schedule.GetFlexStatus(now)
+	if s == nil {
+		return nil
+	}
+	info := &FlexStatusInfo{
+		Late:    s.IsLate(),
+		LeaveAt: s.GetLeaveTime().UnixMilli(),
+		Leave:   s.GetLeaveTime().Format("15:04"),
+		Balance: int(s.GetBalance() / time.Minute),
+	}
+	if s.IsRecorded() {
+		info.Arrival = s.GetArrival().Format("15:04")
+	}
+	flex := a.schedule.GetFlex()
+	a.mu.RLock()
+	arrivalErr := a.arrivalErr
+	a.mu.RUnlock()
+	switch {
+	case arrivalErr != nil:
+		info.Warning = "到岗时刻保存失败: " + arrivalErr.Error()
+	case !s.IsRecorded():
+		info.Warning = fmt.Sprintf("未记录到岗时间，按%s到岗计算", schedule.FormatTimeOfDay(flex.GetLatest()))
+	case s.IsShort() && s.GetWorked() > 0:
+		info.Warning = fmt.Sprintf("还差%d分钟满%s", (-s.GetBalance()+time.Minute-1)/time.Minute, formatHours(flex.GetDuration()))
+	case s.IsLate():
+		info.Warning = fmt.Sprintf("迟到，晚于%s到岗", schedule.FormatTimeOfDay(flex.GetLatest()))
+	}
+	return info
+}
+
+// formatHours 把时长格式化为“9小时”、“8小时30分钟”
+func formatHours(d time.Duration) string {
+	h, m := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	if m == 0 {
+		return fmt.Sprintf("%d小时", h)
+	}
+	return fmt.Sprintf("%d小时%d分钟", h, m)
+}
+
+// SetArrival 设置今天的到岗时刻，如“09:12”，传空字符串清除（按最晚到岗时刻计算），保存后重启仍有效
+func (a *App) SetArrival(value string) error {
+	now := a.now().In(festival.CalendarLocation())
+	var arrival time.Time
+	if value != "" {
+		d, err := schedule.ParseTimeOfDay(value)
+		if err != nil {
+			return err
+		}
+		arrival = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Add(d)
+		if arrival.After(now) {
+			return fmt.Errorf("到岗时刻不能晚于现在: %s", value)
+		}
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.setArrival(now.Format(time.DateOnly), arrival, false)
+}
+
+// attendanceTimeLayout 打卡时刻的格式（北京时间）
+const attendanceTimeLayout = "2006-01-02 15:04"
+
+// AttendanceRecordInfo 打卡记录信息结构（返回给前端）
+type AttendanceRecordInfo struct {
+	ID   int    `json:"id"`
+	Kind string `json:"kind"`
+	// Time 打卡时刻，如“2026-09-21 09:12”，At 为Unix毫秒
+	Time   string `json:"time"`
+	At     int64  `json:"at"`
+	Note   string `json:"note"`
+	Edited bool   `json:"edited"`
+}
+
+// AttendanceSessionInfo 一次上下班信息结构（返回给前端），还没下班打卡时Out为nil
+type AttendanceSessionInfo struct {
+	In      AttendanceRecordInfo  `json:"in"`
+	Out     *AttendanceRecordInfo `json:"out"`
+	Minutes int                   `json:"minutes"`
+}
+
+// AttendanceInfo 一段时间的考勤信息结构（返回给前端）
+type AttendanceInfo struct {
+	Period     string                  `json:"period"`
+	PeriodName string                  `json:"periodName"`
+	From       string                  `json:"from"`
+	To         string                  `json:"to"`
+	Days       int                     `json:"days"`
+	Minutes    int                     `json:"minutes"`
+	ClockedIn  bool                    `json:"clockedIn"`
+	Sessions   []AttendanceSessionInfo `json:"sessions"`
+}
+
+// attendancePeriods 考勤时间段在前端的标识
+var attendancePeriods = map[string]attendance.Period{
+	"day":   attendance.PeriodDay,
+	"week":  attendance.PeriodWeek,
+	"month": attendance.PeriodMonth,
+}
+
+// newAttendanceRecordInfo 转换打卡记录
+func newAttendanceRecordInfo(r attendance.Record) *AttendanceRecordInfo {
+	kind := "in"
+	if r.GetKind() == attendance.ClockOut {
+		kind = "out"
+	}
+	t := r.GetTime().In(festival.CalendarLocation())
+	return &AttendanceRecordInfo{ID: r.GetID(), Kind: kind, Time: t.Format(attendanceTimeLayout), At: t.UnixMilli(), Note: r.GetNote(), Edited: r.IsEdited()}
+}
+
+// attendanceStore 获取打卡记录，打开失败时返回错误
+func (a *App) attendanceStore() (*attendance.Store, error) {
+	if a.attendance == nil {
+		return nil, errors.Join(errors.New("打卡记录不可用"), a.attendanceErr)
+	}
+	return a.attendance, nil
+}
+
+// ClockIn 上班打卡
+func (a *App) ClockIn() (*AttendanceRecordInfo, error) {
+	store, err := a.attendanceStore()
+	if err != nil {
+		return nil, err
+	}
+	r, err := store.ClockIn(a.now())
+	if err != nil {
+		return nil, err
+	}
+	return newAttendanceRecordInfo(r), nil
+}
+
+// ClockOut 下班打卡
+func (a *App) ClockOut() (*AttendanceRecordInfo, error) {
+	store, err := a.attendanceStore()
+	if err != nil {
+		return nil, err
+	}
+	r, err := store.ClockOut(a.now())
+	if err != nil {
+		return nil, err
+	}
+	return newAttendanceRecordInfo(r), nil
+}
+
+// EditAttendance 修改打卡时间，如“2026-09-21 09:00”（北京时间）
+func (a *App) EditAttendance(id int, value string) (*AttendanceRecordInfo, error) {
+	store, err := a.attendanceStore()
+	if err != nil {
+		return nil, err
+	}
+	t, err := time.ParseInLocation(attendanceTimeLayout, value, festival.CalendarLocation())
+	if err != nil {
+		return nil, fmt.Errorf("时间格式应为 %s: %w", attendanceTimeLayout, err)
+	}
+	r, err := store.Edit(id, t)
+	if err != nil {
+		return nil, err
+	}
+	return newAttendanceRecordInfo(r), nil
+}
+
+// AnnotateAttendance 给打卡记录加备注，传空字符串清除
+func (a *App) AnnotateAttendance(id int, note string) (*AttendanceRecordInfo, error) {
+	store, err := a.attendanceStore()
+	if err != nil {
+		return nil, err
+	}
+	r, err := store.Annotate(id, note)
+	if err != nil {
+		return nil, err
+	}
+	return newAttendanceRecordInfo(r), nil
+}
+
+// GetAttendance 获取今天（day）、本周（week）或本月（month）的考勤，跨零点的上下班计入上班那天
+func (a *App) GetAttendance(period string) (*AttendanceInfo, error) {
+	store, err := a.attendanceStore()
+	if err != nil {
+		return nil, err
+	}
+	p, ok := attendancePeriods[period]
+	if !ok {
+		return nil, fmt.Errorf("非法时间段: %q，应为day、week或month", period)
+	}
+	now := a.now().In(festival.CalendarLocation())
+	sum := store.Summarize(p, now)
+	info := &AttendanceInfo{
+		Period:     period,
+		PeriodName: p.String(),
+		From:       sum.GetFrom().Format(time.DateOnly),
+		To:         sum.GetTo().AddDate(0, 0, -1).Format(time.DateOnly),
+		Days:       sum.GetDays(),
+		Minutes:    int(sum.GetWorked(now) / time.Minute),
+		ClockedIn:  store.IsClockedIn(),
+		Sessions:   []AttendanceSessionInfo{},
+	}
+	for _, s := range sum.GetSessions() {
+		session := AttendanceSessionInfo{In: *newAttendanceRecordInfo(s.GetIn()), Minutes: int(s.GetDuration(now) / time.Minute)}
+		if out := s.GetOut(); out != nil {
+			session.Out = newAttendanceRecordInfo(*out)
+		}
+		info.Sessions = append(info.Sessions, session)
+	}
+	return info, nil
+}
+
+// OvertimeInfo 本月加班信息结构（返回给前端）
+type OvertimeInfo struct {
+	Month      string                 `json:"month"`
+	HourlyRate float64                `json:"hourlyRate"`
+	Minutes    int                    `json:"minutes"`
+	Pay        float64                `json:"pay"`
+	Categories []OvertimeCategoryInfo `json:"categories"`
+}
 
+// OvertimeCategoryInfo 一类加班的信息结构（返回给前端）
+type OvertimeCategoryInfo struct {
+	Name       string  `json:"name"`
+	Multiplier float64 `json:"multiplier"`
+	Minutes    int     `json:"minutes"`
+	Pay        float64 `json:"pay"`
+}
+
+// isStatutoryHoliday 按节假日日历判断某天是否为法定假日（加班三倍工资）
+func (a *App) isStatutoryHoliday(day time.Time) bool {
+	return a.calendar.IsStatutoryHoliday(festival.NewSolarDayFromTime(day.In(festival.CalendarLocation())))
+}
+
+// GetMonthlyWorkDays 获取月计薪天数（21.75天），日工资 = 月工资 ÷ 月计薪天数，与加班工资的计算一致
+func (a *App) GetMonthlyWorkDays() float64 {
+	return overtime.MonthlyWorkDays
+}
+
+// GetOvertime 按本月的打卡记录、作息和法定假日计算加班时长和加班工资，monthlySalary为月工资
+// 还没下班打卡的算到现在
+func (a *App) GetOvertime(monthlySalary float64) (*OvertimeInfo, error) {
+	store, err := a.attendanceStore()
+	if err != nil {
+		return nil, err
+	}
+	engine, err := overtime.NewEngine(a.schedule, a.isStatutoryHoliday, monthlySalary)
+	if err != nil {
+		return nil, err
+	}
+	now := a.now().In(festival.CalendarLocation())
+	sum := store.Summarize(attendance.PeriodMonth, now)
+	spans := make([]overtime.Span, 0, len(sum.GetSessions()))
+	for _, s := range sum.GetSessions() {
+		start := s.GetIn().GetTime().In(festival.CalendarLocation())
+		spans = append(spans, overtime.Span{Start: start, End: start.Add(s.GetDuration(now))})
+	}
+	report := engine.Compute(spans...)
+	info := &OvertimeInfo{
+		Month:      sum.GetFrom().Format("2006-01"),
+		HourlyRate: engine.GetHourlyRate(),
+		Minutes:    int(report.GetTotalDuration() / time.Minute),
+		Pay:        report.GetTotalPay(),
+	}
+	for c := range overtime.CategoryNames {
+		category := overtime.Category(c)
+		info.Categories = append(info.Categories, OvertimeCategoryInfo{
+			Name:       category.String(),
+			Multiplier: category.GetMultiplier(),
+			Minutes:    int(report.GetDuration(category) / time.Minute),
+			Pay:        report.GetPay(category),
+		})
+	}
+	return info, nil
+}
+
+// GetWeekendCountdown 获取距离本轮最后一个工作日的天数（即“周五”倒计时）
+// 今天是休息日时，计算到下一轮工作的最后一天
+func (a *App) GetWeekendCountdown() int {
+	today := a.today()
+	start := a.calendar.NextWorkday(today)
+	rest := a.calendar.NextRestDay(start)
+	return today.DaysUntil(rest) - 1
+}
+
+// GetPaydayCountdown 获取距离下一个发薪日的天数（发薪日当天为0），payday大于当月天数时按月末计
+func (a *App) GetPaydayCountdown(payday int) int {
+	today := a.today()
+	year, month := today.GetYear(), today.GetMonth()
+	next := paydayOf(year, month, payday)
+	if today.DaysUntil(next) < 0 {
+		if month++; month > 12 {
+			year, month = year+1, 1
+		}
+		next = paydayOf(year, month, payday)
+	}
+	return today.DaysUntil(next)
+}
+
+// paydayOf 获取某月的发薪日，payday大于当月天数时为月末
+func paydayOf(year int, month int, payday int) festival.SolarDay {
+	d, _ := festival.NewSolarDay(year, month, max(1, min(payday, festival.GetSolarMonthDays(year, month))))
+	return d
+}
+
+// GanZhiInfo 干支信息结构（返回给前端）
+type GanZhiInfo struct {
+	Year  string `json:"year"`
+	Month string `json:"month"`
+	Day   string `json:"day"`
+	Hour  string `json:"hour"`
+	Sound string `json:"sound"`
+}
+
+// GetGanZhi 获取当前时刻的干支（年以立春为界，月以节令为界），如“乙巳年 戊寅月 甲子日 子时”
+func (a *App) GetGanZhi() *GanZhiInfo {
+	now := a.nowDay()
+	year := now.GetYearSixtyCycle()
+	return &GanZhiInfo{
+		Year:  year.GetName() + "年",
+		Month: now.GetMonthSixtyCycle().GetName() + "月",
+		Day:   now.GetDaySixtyCycle().GetName() + "日",
+		Hour:  now.GetHourSixtyCycle().GetEarthBranch().GetName() + "时",
+		Sound: year.GetSound(),
+	}
+}
+
+// LunarDateInfo 农历日期信息结构（返回给前端）
+type LunarDateInfo struct {
+	Text  string `json:"text"`
+	Full  string `json:"full"`
+	Month string `json:"month"`
+	Day   string `json:"day"`
+	Leap  bool   `json:"leap"`
+	Big   bool   `json:"big"`
+}
+
+// GetLunarDate 获取今天的农历日期，如“腊月廿三”、“农历二〇二六年腊月廿三”
+// 今天超出支持范围（公历1-9999年）时返回错误，不显示错误的日期
+func (a *App) GetLunarDate() (*LunarDateInfo, error) {
+	lunarDay, err := festival.LunarDayOf(a.nowDay())
+	if err != nil {
+		return nil, err
+	}
+	month, err := festival.NewLunarMonth(lunarDay.GetYear(), lunarDay.GetMonth())
+	if err != nil {
+		return nil, err
+	}
+	return &LunarDateInfo{
+		Text:  lunarDay.Format("%M%D"),
+		Full:  lunarDay.String(),
+		Month: month.Format("%M%S"),
+		Day:   lunarDay.GetName(),
+		Leap:  month.IsLeap(),
+		Big:   month.IsBig(),
+	}, nil
+}
+
+// SolarTermInfo 节气信息结构（返回给前端）
+type SolarTermInfo struct {
+	Name string `json:"name"`
+	Time string `json:"time"`
+	Days int    `json:"days"`
+}
+
+// GetNextSolarTerm 获取从今天起最近的节气及其交节时刻（北京时间），如“立春 02-04 04:02”
+func (a *App) GetNextSolarTerm() *SolarTermInfo {
+	today := a.today()
+	term := today.GetNearestSolarTerm()
+	t := term.GetSolarTime()
+	return &SolarTermInfo{
+		Name: term.GetName(),
+		Time: fmt.Sprintf("%02d-%02d %02d:%02d", t.GetMonth(), t.GetDay(), t.GetHour(), t.GetMinute()),
+		Days: today.DaysUntil(term.GetSolarDay()),
+	}
+}
+
+// WeekInfo 星期信息结构（返回给前端）
+type WeekInfo struct {
+	Week        string `json:"week"`
+	ISOYear     int    `json:"isoYear"`
+	ISOWeek     int    `json:"isoWeek"`
+	WeekOfMonth int    `json:"weekOfMonth"`
+}
+
+// GetWeekInfo 获取今天的星期和周数（每周从星期一开始）
+func (a *App) GetWeekInfo() *WeekInfo {
+	today := a.today()
+	year, week := today.GetISOWeek()
+	return &WeekInfo{
+		Week:        today.GetWeek().String(),
+		ISOYear:     year,
+		ISOWeek:     week,
+		WeekOfMonth: today.GetWeekOfMonth(festival.NewWeek(1)),
+	}
+}
+
+// SetShowSeasons 设置节日倒计时是否包含数九、三伏
+func (a *App) SetShowSeasons(show bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.showSeasons = show
+}
+
+// GetShowSeasons 获取节日倒计时是否包含数九、三伏
+func (a *App) GetShowSeasons() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.showSeasons
+}
+
+// GetSeasonInfo 获取今天所处的数九、三伏，如“三九 第4天”，不在其中返回空字符串
+func (a *App) GetSeasonInfo() string {
+	today := a.today()
+	if d := today.GetShuJiu(); d != nil {
+		return d.String()
+	}
+	if d := today.GetDogDay(); d != nil {
+		return d.String()
+	}
+	return ""
+}
+
+// GetLastFestival 获取上一个节日，Days为已过去的天数
+func (a *App) GetLastFestival() *FestivalInfo {
+	today := a.today()
+	festivals := today.GetPreviousFestivals(60, a.festivalOptions()...)
+	if len(festivals) == 0 {
+		return &FestivalInfo{Name: "无"}
+	}
+	names := make([]string, 0, len(festivals))
+	for _, f := range festivals {
+		names = append(names, f.Name)
+	}
 	return &FestivalInfo{
-		Name: f.Name,
-		Days: days,
-		Type: f.Type.String(),
+		Name:  strings.Join(names, " · "),
+		Names: names,
+		Days:  festivals[0].DaysSince(today),
+		Type:  festivals[0].Type.String(),
+	}
+}
+
+// ============ 开发调试 ============
+
+// simulatedTimeLayout 模拟时间的格式（北京时间）
+const simulatedTimeLayout = "2006-01-02 15:04"
+
+// IsDevBuild 是否为开发构建（wails dev），模拟时间等调试功能只在开发构建中可用
+func (a *App) IsDevBuild() bool {
+	return a.ctx != nil && runtime.Environment(a.ctx).BuildType == "dev"
+}
+
+// GetNow 获取当前时刻（Unix毫秒），模拟时间时为模拟的时刻，前端据此校准自己的时钟
+func (a *App) GetNow() int64 {
+	return a.now().UnixMilli()
+}
+
+// SetSimulatedTime 模拟当前时刻（仅开发构建），如“2026-02-16 18:59”，之后时间照常流逝
+// 传空字符串恢复为系统时间
+func (a *App) SetSimulatedTime(value string) error {
+	if !a.IsDevBuild() {
+		return errors.New("模拟时间仅在开发构建中可用")
+	}
+	var clock festival.Clock = festival.SystemClock{}
+	if value != "" {
+		t, err := time.ParseInLocation(simulatedTimeLayout, value, festival.CalendarLocation())
+		if err != nil {
+			return fmt.Errorf("时间格式应为 %s: %w", simulatedTimeLayout, err)
+		}
+		if _, err := festival.NewSolarDay(t.Year(), int(t.Month()), t.Day()); err != nil {
+			return err
+		}
+		clock = festival.NewOffsetClockAt(nil, t)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.clock = clock
+	return nil
+}
+
+// GetSimulatedTime 获取模拟的当前时刻，如“2026-02-16 18:59”，未模拟时返回空字符串
+func (a *App) GetSimulatedTime() string {
+	a.mu.RLock()
+	clock := a.clock
+	a.mu.RUnlock()
+	if _, ok := clock.(festival.OffsetClock); !ok {
+		return ""
 	}
+	return clock.Now().In(festival.CalendarLocation()).Format(simulatedTimeLayout)
 }
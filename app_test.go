@@ -0,0 +1,348 @@
+package main
+
+import (
+	"errors"
+	"math"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"workoff-timer/internal/attendance"
+	"workoff-timer/internal/festival"
+	"workoff-timer/internal/schedule"
+)
+
+// TestAppWithFixedClock 固定时钟下各项信息测试（2026-02-16 18:59，除夕）
+func TestAppWithFixedClock(t *testing.T) {
+	at := time.Date(2026, 2, 16, 18, 59, 0, 0, festival.CalendarLocation())
+	a := newApp(festival.NewFixedClock(at))
+
+	if f := a.GetNextFestival(); f.Name != "除夕" || f.Days != 0 {
+		t.Errorf("期望除夕 0天, 实际 %+v", f)
+	}
+	if d := a.GetDayInfo(); d.Date != "2026年2月16日" || !d.Holiday || d.HolidayName != "春节" {
+		t.Errorf("期望春节假期, 实际 %+v", d)
+	}
+	if g := a.GetGanZhi(); g.Hour != "酉时" {
+		t.Errorf("期望酉时, 实际 %+v", g)
+	}
+	if l, err := a.GetLunarDate(); err != nil || l.Text != "腊月廿九" {
+		t.Errorf("期望腊月廿九, 实际 %+v %v", l, err)
+	}
+	if n := a.GetNow(); n != at.UnixMilli() {
+		t.Errorf("期望 %d, 实际 %d", at.UnixMilli(), n)
+	}
+
+	// 本机时区不影响日期：UTC 2026-02-16 16:30 已是北京时间2月17日（春节）
+	a = newApp(festival.NewFixedClock(time.Date(2026, 2, 16, 16, 30, 0, 0, time.UTC)))
+	if f := a.GetNextFestival(); f.Name != "春节" || f.Days != 0 {
+		t.Errorf("期望春节 0天, 实际 %+v", f)
+	}
+
+	// 超出支持范围时报告错误，不显示错误的农历日期
+	a = newApp(festival.NewFixedClock(time.Date(festival.MaxYear+1, 1, 1, 9, 0, 0, 0, festival.CalendarLocation())))
+	if l, err := a.GetLunarDate(); !errors.Is(err, festival.ErrOutOfRange) {
+		t.Errorf("期望ErrOutOfRange, 实际 %+v %v", l, err)
+	}
+
+	// 非开发构建不能模拟时间
+	if err := a.SetSimulatedTime("2026-10-01 09:00"); err == nil {
+		t.Errorf("非开发构建不应允许模拟时间")
+	}
+}
+
+// TestCountdown 假期进度与倒计时
+func TestCountdown(t *testing.T) {
+	// 国庆中秋假期中：第3天/共8天，6天后上班
+	a := newApp(festival.NewFixedClock(time.Date(2025, 10, 3, 10, 0, 0, 0, festival.CalendarLocation())))
+	if s := a.GetHolidayStatus(); !s.InProgress || s.Day != 3 || s.Days != 8 || s.BackToWorkDays != 6 || a.GetBackToWorkCountdown() != 6 {
+		t.Errorf("期望第3天/共8天 6天后上班, 实际 %+v", s)
+	}
+	if f := a.GetNextFestival(); f.Name != "中秋节" || f.HolidayDays != 8 {
+		t.Errorf("期望中秋节放假8天, 实际 %+v", f)
+	}
+
+	// 倒计时天数只在零点变化：下午与上午相同
+	for _, hour := range []int{0, 9, 13, 23} {
+		a = newApp(festival.NewFixedClock(time.Date(2026, 9, 21, hour, 30, 0, 0, festival.CalendarLocation())))
+		if f := a.GetNextFestival(); f.Name != "秋分" || f.Days != 2 {
+			t.Errorf("%d点 期望秋分 2天, 实际 %+v", hour, f)
+		}
+		if d := a.GetPaydayCountdown(10); d != 19 {
+			t.Errorf("%d点 期望发薪 19天, 实际 %d", hour, d)
+		}
+		if d := a.GetPaydayCountdown(31); d != 9 {
+			t.Errorf("%d点 期望月末发薪 9天, 实际 %d", hour, d)
+		}
+	}
+}
+
+// TestWorkStatus 上下班状态，调休上班与法定节假日
+func TestWorkStatus(t *testing.T) {
+	// 上班中：周一14:00，午休不计薪，已工作3.5小时/共8.5小时，5小时后下班
+	a := newApp(festival.NewFixedClock(time.Date(2026, 9, 21, 14, 0, 0, 0, festival.CalendarLocation())))
+	if w := a.GetWorkStatus(); w.Phase != "working" || w.Progress != 3.5/8.5 || w.Seconds != 5*3600 || w.Window != "09:00-19:00" || len(w.Segments) != 2 {
+		t.Errorf("期望上班中, 实际 %+v", w)
+	}
+	// 调休上班的周六按标准时段上班，法定节假日休息
+	a = newApp(festival.NewFixedClock(time.Date(2026, 10, 10, 8, 0, 0, 0, festival.CalendarLocation())))
+	if w := a.GetWorkStatus(); w.Phase != "before" || w.Seconds != 3600 {
+		t.Errorf("期望调休日未上班, 实际 %+v", w)
+	}
+	a = newApp(festival.NewFixedClock(time.Date(2026, 10, 1, 10, 0, 0, 0, festival.CalendarLocation())))
+	next := time.Date(2026, 10, 8, 9, 0, 0, 0, festival.CalendarLocation())
+	if w := a.GetWorkStatus(); w.Phase != "off" || w.NextAt != next.UnixMilli() || w.Window != "" {
+		t.Errorf("期望国庆休息到10月8日, 实际 %+v", w)
+	}
+}
+
+// TestBreaks 午休倒计时与午休中进度暂停
+func TestBreaks(t *testing.T) {
+	// 午休前：午休还有00:23:10
+	a := newApp(festival.NewFixedClock(time.Date(2026, 9, 21, 11, 36, 50, 0, festival.CalendarLocation())))
+	if w := a.GetWorkStatus(); w.NextBreak != "午休" || w.Seconds != 23*60+10 {
+		t.Errorf("期望午休还有00:23:10, 实际 %+v", w)
+	}
+	// 午休中：进度暂停
+	a = newApp(festival.NewFixedClock(time.Date(2026, 9, 21, 12, 45, 0, 0, festival.CalendarLocation())))
+	if w := a.GetWorkStatus(); w.Phase != "break" || w.Break != "午休" || w.Progress != 3/8.5 || w.Seconds != 45*60 {
+		t.Errorf("期望午休中, 实际 %+v", w)
+	}
+}
+
+// TestRotation 轮班与跨零点的夜班
+func TestRotation(t *testing.T) {
+	// 夜班跨过零点仍在上班，收入按上班那天计
+	opts, err := schedule.LoadConfig("schedule.json", strings.NewReader(`{"version": 1, "rotation": {"anchor": "2026-09-01",
+		"pattern": [{"name": "白班", "start": "08:00", "end": "20:00"}, {"name": "夜班", "start": "20:00", "end": "08:00"}, null, null]}}`), festival.CalendarLocation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := newApp(festival.NewFixedClock(time.Date(2026, 9, 3, 2, 0, 0, 0, festival.CalendarLocation())))
+	a.schedule = a.newSchedule(opts...)
+	start := time.Date(2026, 9, 2, 20, 0, 0, 0, festival.CalendarLocation())
+	if w := a.GetWorkStatus(); w.Phase != "working" || w.Shift != "夜班" || w.StartAt != start.UnixMilli() || w.Seconds != 6*3600 || w.NextShift != "白班" ||
+		w.NextShiftAt != time.Date(2026, 9, 5, 8, 0, 0, 0, festival.CalendarLocation()).UnixMilli() {
+		t.Errorf("期望夜班上班中, 实际 %+v", w)
+	}
+}
+
+// TestFlex 弹性工作时间与到岗记录
+func TestFlex(t *testing.T) {
+	// 9:12到岗，工作9小时（含午休）18:12下班，17:00时还差72分钟
+	opts, err := schedule.LoadConfig("schedule.json", strings.NewReader(`{"version": 1, "flex": {"earliest": "08:00", "latest": "10:00", "duration": "9h"}}`),
+		festival.CalendarLocation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := newApp(festival.NewFixedClock(time.Date(2026, 9, 21, 17, 0, 0, 0, festival.CalendarLocation())))
+	a.schedule = a.newSchedule(opts...)
+	if f := a.GetFlexStatus(); f == nil || f.Arrival != "" || f.Leave != "19:00" || f.Warning != "未记录到岗时间，按10:00到岗计算" {
+		t.Errorf("期望按10:00到岗计算, 实际 %+v", f)
+	}
+	if err := a.SetArrival("17:30"); err == nil {
+		t.Errorf("到岗时刻晚于现在应报错")
+	}
+	if err := a.SetArrival("09:12"); err != nil {
+		t.Fatal(err)
+	}
+	if f := a.GetFlexStatus(); f == nil || f.Arrival != "09:12" || f.Leave != "18:12" || f.Balance != -72 || f.Warning != "还差72分钟满9小时" {
+		t.Errorf("期望18:12下班, 实际 %+v", f)
+	}
+	if w := a.GetWorkStatus(); w.Seconds != 72*60 || w.Window != "09:12-18:12" {
+		t.Errorf("期望72分钟后下班, 实际 %+v", w)
+	}
+}
+
+// TestFlexArrival 到岗时刻自动记录、保存，重启后不变
+func TestFlexArrival(t *testing.T) {
+	opts, err := schedule.LoadConfig("schedule.json", strings.NewReader(`{"version": 1, "flex": {"earliest": "08:00", "latest": "10:00", "duration": "9h"}}`),
+		festival.CalendarLocation())
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "arrival.json")
+	start := func(at time.Time) *App {
+		a := newApp(festival.NewFixedClock(at))
+		a.schedule = a.newSchedule(opts...)
+		if err := a.loadArrival(path); err != nil {
+			t.Fatal(err)
+		}
+		a.recordArrival()
+		return a
+	}
+	at := func(d, h, m int) time.Time { return time.Date(2026, 9, d, h, m, 0, 0, festival.CalendarLocation()) }
+
+	// 当天第一次启动记为到岗，同一天重启不变
+	a := start(at(22, 8, 45))
+	if f := a.GetFlexStatus(); f == nil || f.Arrival != "08:45" || f.Leave != "17:45" || f.Warning != "" {
+		t.Errorf("期望8:45到岗, 实际 %+v", f)
+	}
+	a = start(at(22, 9, 30))
+	if f := a.GetFlexStatus(); f == nil || f.Arrival != "08:45" {
+		t.Errorf("重启后期望仍为8:45到岗, 实际 %+v", f)
+	}
+	// 手动设置、清除也会保存
+	if err := a.SetArrival("08:20"); err != nil {
+		t.Fatal(err)
+	}
+	if f := start(at(22, 9, 40)).GetFlexStatus(); f == nil || f.Arrival != "08:20" {
+		t.Errorf("重启后期望8:20到岗, 实际 %+v", f)
+	}
+	if err := a.SetArrival(""); err != nil {
+		t.Fatal(err)
+	}
+	if f := start(at(22, 9, 50)).GetFlexStatus(); f == nil || f.Arrival != "" {
+		t.Errorf("清除后重启不应重新记录, 实际 %+v", f)
+	}
+
+	// 一直开着过夜，第二天第一次查询状态时记为到岗
+	a.mu.Lock()
+	a.clock = festival.NewFixedClock(at(23, 8, 10))
+	a.mu.Unlock()
+	if w := a.GetWorkStatus(); w.Window != "08:10-17:10" {
+		t.Errorf("期望8:10到岗, 实际 %+v", w)
+	}
+	if f := start(at(23, 12, 0)).GetFlexStatus(); f == nil || f.Arrival != "08:10" {
+		t.Errorf("重启后期望8:10到岗, 实际 %+v", f)
+	}
+
+	// 开着过夜，零点后第一次查询不在到岗时段内，不记为到岗
+	a.mu.Lock()
+	a.clock = festival.NewFixedClock(at(24, 0, 1))
+	a.mu.Unlock()
+	if f := a.GetFlexStatus(); f == nil || f.Arrival != "" {
+		t.Errorf("零点后不应记为到岗, 实际 %+v", f)
+	}
+	a.mu.Lock()
+	a.clock = festival.NewFixedClock(at(24, 9, 45))
+	a.mu.Unlock()
+	if f := a.GetFlexStatus(); f == nil || f.Arrival != "09:45" || f.Leave != "18:45" {
+		t.Errorf("期望9:45到岗, 实际 %+v", f)
+	}
+
+	// 上班打卡优先于自动记录的到岗时刻：8:05在家打开了应用，9:00到公司打卡
+	store, err := attendance.Open(filepath.Join(t.TempDir(), "attendance.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	a = start(at(28, 8, 5))
+	a.attendance = store
+	if f := a.GetFlexStatus(); f == nil || f.Arrival != "08:05" {
+		t.Errorf("期望自动记录8:05到岗, 实际 %+v", f)
+	}
+	a.mu.Lock()
+	a.clock = festival.NewFixedClock(at(28, 9, 0))
+	a.mu.Unlock()
+	if _, err := a.ClockIn(); err != nil {
+		t.Fatal(err)
+	}
+	if f := a.GetFlexStatus(); f == nil || f.Arrival != "09:00" || f.Leave != "18:00" {
+		t.Errorf("期望按打卡9:00到岗, 实际 %+v", f)
+	}
+	// 手动设置的仍优先于打卡
+	if err := a.SetArrival("08:30"); err != nil {
+		t.Fatal(err)
+	}
+	if f := a.GetFlexStatus(); f == nil || f.Arrival != "08:30" {
+		t.Errorf("期望手动设置的8:30到岗, 实际 %+v", f)
+	}
+}
+
+// TestAttendance 打卡、修改、备注及考勤查询测试
+func TestAttendance(t *testing.T) {
+	at := func(d, h, m int) time.Time { return time.Date(2026, 9, d, h, m, 0, 0, festival.CalendarLocation()) }
+	a := newApp(festival.NewFixedClock(at(21, 9, 5)))
+	if _, err := a.ClockIn(); err == nil {
+		t.Errorf("没有打开打卡记录时应报错")
+	}
+	store, err := attendance.Open(filepath.Join(t.TempDir(), "attendance.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	a.attendance = store
+
+	if r, err := a.ClockIn(); err != nil || r.Kind != "in" || r.Time != "2026-09-21 09:05" {
+		t.Fatalf("上班打卡失败: %+v %v", r, err)
+	}
+	if _, err := a.ClockIn(); !errors.Is(err, attendance.ErrAlreadyClockedIn) {
+		t.Errorf("期望ErrAlreadyClockedIn, 实际 %v", err)
+	}
+	a.clock = festival.NewFixedClock(at(21, 18, 35))
+	if _, err := a.ClockOut(); err != nil {
+		t.Fatal(err)
+	}
+	if r, err := a.EditAttendance(2, "2026-09-21 19:05"); err != nil || !r.Edited || r.Time != "2026-09-21 19:05" {
+		t.Errorf("修改失败: %+v %v", r, err)
+	}
+	if _, err := a.EditAttendance(2, "19:05"); err == nil {
+		t.Errorf("时间格式错误应报错")
+	}
+	if r, err := a.AnnotateAttendance(2, "上线"); err != nil || r.Note != "上线" {
+		t.Errorf("备注失败: %+v %v", r, err)
+	}
+
+	a.clock = festival.NewFixedClock(at(22, 10, 0))
+	if _, err := a.ClockIn(); err != nil {
+		t.Fatal(err)
+	}
+	a.clock = festival.NewFixedClock(at(22, 12, 0))
+	if d, err := a.GetAttendance("day"); err != nil || d.Minutes != 120 || !d.ClockedIn || len(d.Sessions) != 1 || d.Sessions[0].Out != nil {
+		t.Errorf("期望今天已工作2小时, 实际 %+v %v", d, err)
+	}
+	if w, err := a.GetAttendance("week"); err != nil || w.Days != 2 || w.Minutes != 10*60+120 || w.From != "2026-09-21" || w.To != "2026-09-27" {
+		t.Errorf("期望本周2天12小时, 实际 %+v %v", w, err)
+	}
+	if _, err := a.GetAttendance("year"); err == nil {
+		t.Errorf("非法时间段应报错")
+	}
+	// 打卡作为弹性工作时间的到岗时刻
+	if arrival, ok := a.arrivalOn(at(22, 0, 0)); !ok || !arrival.Equal(at(22, 10, 0)) {
+		t.Errorf("期望10:00到岗, 实际 %s %v", arrival, ok)
+	}
+
+	// 加班：9月21日19:05下班、9月22日20:00下班为工作日加班，9月26日（中秋假期）为休息日加班
+	a.clock = festival.NewFixedClock(at(22, 20, 0))
+	a.ClockOut()
+	a.clock = festival.NewFixedClock(at(26, 10, 0))
+	a.ClockIn()
+	a.clock = festival.NewFixedClock(at(26, 12, 0))
+	a.ClockOut()
+	o, err := a.GetOvertime(21750)
+	if err != nil || o.Month != "2026-09" || o.HourlyRate != 125 || o.Minutes != 185 || len(o.Categories) != 3 ||
+		o.Categories[0].Minutes != 65 || o.Categories[1].Minutes != 120 || o.Categories[2].Minutes != 0 {
+		t.Fatalf("期望工作日65分钟、休息日120分钟, 实际 %+v %v", o, err)
+	}
+	if pay := 125 * (65.0/60*1.5 + 2*2); math.Abs(o.Pay-pay) > 1e-9 {
+		t.Errorf("期望加班费 %v, 实际 %v", pay, o.Pay)
+	}
+
+	// 法定假日按应用的节假日日历判断：把9月26日加为法定假日后按三倍计
+	day, _ := festival.NewSolarDay(2026, 9, 26)
+	h, _ := festival.NewHoliday("测试", day, day)
+	h, _ = h.WithStatutoryDays(day)
+	a.calendar.Add(h)
+	if o, err := a.GetOvertime(21750); err != nil || o.Categories[1].Minutes != 0 || o.Categories[2].Minutes != 120 {
+		t.Errorf("期望法定假日120分钟, 实际 %+v %v", o, err)
+	}
+}
+
+// TestShowSeasonsConcurrent 绑定方法在不同goroutine中调用，用 go test -race 检查
+func TestShowSeasonsConcurrent(t *testing.T) {
+	a := newApp(festival.NewFixedClock(time.Date(2026, 1, 10, 9, 0, 0, 0, festival.CalendarLocation())))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			a.SetShowSeasons(i%2 == 0)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		a.GetNextFestival()
+		a.GetShowSeasons()
+	}
+	<-done
+}
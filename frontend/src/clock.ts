@@ -0,0 +1,14 @@
+import {GetNow} from "../wailsjs/go/main/App";
+
+// 前端时钟与Go端时钟的偏移（毫秒），开发构建中模拟时间时不为0
+let offset = 0;
+
+// now 获取当前时刻，与Go端（可能是模拟的）时钟一致
+export function now(): Date {
+  return new Date(Date.now() + offset);
+}
+
+// syncClock 从Go端校准时钟
+export async function syncClock() {
+  offset = (await GetNow()) - Date.now();
+}
@@ -1,8 +1,10 @@
 import './style.css'
 import App from './App.svelte'
+import {syncClock} from './clock'
 
-const app = new App({
+// 先与Go端校准时钟（开发构建中可能在模拟时间），再渲染界面
+const app = syncClock().catch(console.error).then(() => new App({
   target: document.getElementById('app')
-})
+}))
 
 export default app
@@ -2,6 +2,64 @@
 // This file is automatically generated. DO NOT EDIT
 import {main} from '../models';
 
+export function AnnotateAttendance(arg1:number,arg2:string):Promise<main.AttendanceRecordInfo>;
+
+export function ClockIn():Promise<main.AttendanceRecordInfo>;
+
+export function ClockOut():Promise<main.AttendanceRecordInfo>;
+
+export function EditAttendance(arg1:number,arg2:string):Promise<main.AttendanceRecordInfo>;
+
+export function GetAttendance(arg1:string):Promise<main.AttendanceInfo>;
+
+export function GetBackToWorkCountdown():Promise<number>;
+
+export function GetDayInfo():Promise<main.DayInfo>;
+
+export function GetFestivalDatasetError():Promise<string>;
+
+export function GetFlexStatus():Promise<main.FlexStatusInfo>;
+
+export function GetGanZhi():Promise<main.GanZhiInfo>;
+
+export function GetHolidayStatus():Promise<main.HolidayStatusInfo>;
+
+export function GetLastFestival():Promise<main.FestivalInfo>;
+
+export function GetLunarDate():Promise<main.LunarDateInfo>;
+
+export function GetMonthlyWorkDays():Promise<number>;
+
 export function GetNextFestival():Promise<main.FestivalInfo>;
 
+export function GetNextSolarTerm():Promise<main.SolarTermInfo>;
+
+export function GetNow():Promise<number>;
+
+export function GetOvertime(arg1:number):Promise<main.OvertimeInfo>;
+
+export function GetPaydayCountdown(arg1:number):Promise<number>;
+
+export function GetScheduleError():Promise<string>;
+
+export function GetSeasonInfo():Promise<string>;
+
+export function GetShowSeasons():Promise<boolean>;
+
+export function GetSimulatedTime():Promise<string>;
+
+export function GetWeekInfo():Promise<main.WeekInfo>;
+
+export function GetWeekendCountdown():Promise<number>;
+
+export function GetWorkStatus():Promise<main.WorkStatusInfo>;
+
 export function Greet(arg1:string):Promise<string>;
+
+export function IsDevBuild():Promise<boolean>;
+
+export function SetArrival(arg1:string):Promise<void>;
+
+export function SetShowSeasons(arg1:boolean):Promise<void>;
+
+export function SetSimulatedTime(arg1:string):Promise<void>;
@@ -2,10 +2,126 @@
 // Cynhyrchwyd y ffeil hon yn awtomatig. PEIDIWCH Â MODIWL
 // This file is automatically generated. DO NOT EDIT
 
+export function AnnotateAttendance(arg1, arg2) {
+  return window['go']['main']['App']['AnnotateAttendance'](arg1, arg2);
+}
+
+export function ClockIn() {
+  return window['go']['main']['App']['ClockIn']();
+}
+
+export function ClockOut() {
+  return window['go']['main']['App']['ClockOut']();
+}
+
+export function EditAttendance(arg1, arg2) {
+  return window['go']['main']['App']['EditAttendance'](arg1, arg2);
+}
+
+export function GetAttendance(arg1) {
+  return window['go']['main']['App']['GetAttendance'](arg1);
+}
+
+export function GetBackToWorkCountdown() {
+  return window['go']['main']['App']['GetBackToWorkCountdown']();
+}
+
+export function GetDayInfo() {
+  return window['go']['main']['App']['GetDayInfo']();
+}
+
+export function GetFestivalDatasetError() {
+  return window['go']['main']['App']['GetFestivalDatasetError']();
+}
+
+export function GetFlexStatus() {
+  return window['go']['main']['App']['GetFlexStatus']();
+}
+
+export function GetGanZhi() {
+  return window['go']['main']['App']['GetGanZhi']();
+}
+
+export function GetHolidayStatus() {
+  return window['go']['main']['App']['GetHolidayStatus']();
+}
+
+export function GetLastFestival() {
+  return window['go']['main']['App']['GetLastFestival']();
+}
+
+export function GetLunarDate() {
+  return window['go']['main']['App']['GetLunarDate']();
+}
+
+export function GetMonthlyWorkDays() {
+  return window['go']['main']['App']['GetMonthlyWorkDays']();
+}
+
 export function GetNextFestival() {
   return window['go']['main']['App']['GetNextFestival']();
 }
 
+export function GetNextSolarTerm() {
+  return window['go']['main']['App']['GetNextSolarTerm']();
+}
+
+export function GetNow() {
+  return window['go']['main']['App']['GetNow']();
+}
+
+export function GetOvertime(arg1) {
+  return window['go']['main']['App']['GetOvertime'](arg1);
+}
+
+export function GetPaydayCountdown(arg1) {
+  return window['go']['main']['App']['GetPaydayCountdown'](arg1);
+}
+
+export function GetScheduleError() {
+  return window['go']['main']['App']['GetScheduleError']();
+}
+
+export function GetSeasonInfo() {
+  return window['go']['main']['App']['GetSeasonInfo']();
+}
+
+export function GetShowSeasons() {
+  return window['go']['main']['App']['GetShowSeasons']();
+}
+
+export function GetSimulatedTime() {
+  return window['go']['main']['App']['GetSimulatedTime']();
+}
+
+export function GetWeekInfo() {
+  return window['go']['main']['App']['GetWeekInfo']();
+}
+
+export function GetWeekendCountdown() {
+  return window['go']['main']['App']['GetWeekendCountdown']();
+}
+
+export function GetWorkStatus() {
+  return window['go']['main']['App']['GetWorkStatus']();
+}
+
 export function Greet(arg1) {
   return window['go']['main']['App']['Greet'](arg1);
 }
+
+export function IsDevBuild() {
+  return window['go']['main']['App']['IsDevBuild']();
+}
+
+export function SetArrival(arg1) {
+  return window['go']['main']['App']['SetArrival'](arg1);
+}
+
+export function SetShowSeasons(arg1) {
+  return window['go']['main']['App']['SetShowSeasons'](arg1);
+}
+
+export function SetSimulatedTime(arg1) {
+  return window['go']['main']['App']['SetSimulatedTime'](arg1);
+}
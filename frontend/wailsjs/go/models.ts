@@ -1,9 +1,131 @@
 export namespace main {
 	
+	export class AttendanceInfo {
+	    period: string;
+	    periodName: string;
+	    from: string;
+	    to: string;
+	    days: number;
+	    minutes: number;
+	    clockedIn: boolean;
+	    sessions: AttendanceSessionInfo[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AttendanceInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.period = source["period"];
+	        this.periodName = source["periodName"];
+	        this.from = source["from"];
+	        this.to = source["to"];
+	        this.days = source["days"];
+	        this.minutes = source["minutes"];
+	        this.clockedIn = source["clockedIn"];
+	        this.sessions = this.convertValues(source["sessions"], AttendanceSessionInfo);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class AttendanceRecordInfo {
+	    id: number;
+	    kind: string;
+	    time: string;
+	    at: number;
+	    note: string;
+	    edited: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new AttendanceRecordInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.kind = source["kind"];
+	        this.time = source["time"];
+	        this.at = source["at"];
+	        this.note = source["note"];
+	        this.edited = source["edited"];
+	    }
+	}
+	export class AttendanceSessionInfo {
+	    in: AttendanceRecordInfo;
+	    out: AttendanceRecordInfo;
+	    minutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new AttendanceSessionInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.in = this.convertValues(source["in"], AttendanceRecordInfo);
+	        this.out = this.convertValues(source["out"], AttendanceRecordInfo);
+	        this.minutes = source["minutes"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class DayInfo {
+	    date: string;
+	    workday: boolean;
+	    holiday: boolean;
+	    holidayName: string;
+	    makeup: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new DayInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.workday = source["workday"];
+	        this.holiday = source["holiday"];
+	        this.holidayName = source["holidayName"];
+	        this.makeup = source["makeup"];
+	    }
+	}
 	export class FestivalInfo {
 	    name: string;
+	    names: string[];
 	    days: number;
 	    type: string;
+	    holidayDays: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new FestivalInfo(source);
@@ -12,10 +134,258 @@ export namespace main {
 	    constructor(source: any = {}) {
 	        if ('string' === typeof source) source = JSON.parse(source);
 	        this.name = source["name"];
+	        this.names = source["names"];
 	        this.days = source["days"];
 	        this.type = source["type"];
+	        this.holidayDays = source["holidayDays"];
+	    }
+	}
+	export class FlexStatusInfo {
+	    arrival: string;
+	    late: boolean;
+	    leaveAt: number;
+	    leave: string;
+	    balance: number;
+	    warning: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new FlexStatusInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.arrival = source["arrival"];
+	        this.late = source["late"];
+	        this.leaveAt = source["leaveAt"];
+	        this.leave = source["leave"];
+	        this.balance = source["balance"];
+	        this.warning = source["warning"];
+	    }
+	}
+	export class GanZhiInfo {
+	    year: string;
+	    month: string;
+	    day: string;
+	    hour: string;
+	    sound: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new GanZhiInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.year = source["year"];
+	        this.month = source["month"];
+	        this.day = source["day"];
+	        this.hour = source["hour"];
+	        this.sound = source["sound"];
+	    }
+	}
+	export class HolidayStatusInfo {
+	    inProgress: boolean;
+	    name: string;
+	    day: number;
+	    days: number;
+	    backToWork: string;
+	    backToWorkDays: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new HolidayStatusInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.inProgress = source["inProgress"];
+	        this.name = source["name"];
+	        this.day = source["day"];
+	        this.days = source["days"];
+	        this.backToWork = source["backToWork"];
+	        this.backToWorkDays = source["backToWorkDays"];
 	    }
 	}
+	export class LunarDateInfo {
+	    text: string;
+	    full: string;
+	    month: string;
+	    day: string;
+	    leap: boolean;
+	    big: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new LunarDateInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.text = source["text"];
+	        this.full = source["full"];
+	        this.month = source["month"];
+	        this.day = source["day"];
+	        this.leap = source["leap"];
+	        this.big = source["big"];
+	    }
+	}
+	export class OvertimeCategoryInfo {
+	    name: string;
+	    multiplier: number;
+	    minutes: number;
+	    pay: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new OvertimeCategoryInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.multiplier = source["multiplier"];
+	        this.minutes = source["minutes"];
+	        this.pay = source["pay"];
+	    }
+	}
+	export class OvertimeInfo {
+	    month: string;
+	    hourlyRate: number;
+	    minutes: number;
+	    pay: number;
+	    categories: OvertimeCategoryInfo[];
+	
+	    static createFrom(source: any = {}) {
+	        return new OvertimeInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.month = source["month"];
+	        this.hourlyRate = source["hourlyRate"];
+	        this.minutes = source["minutes"];
+	        this.pay = source["pay"];
+	        this.categories = this.convertValues(source["categories"], OvertimeCategoryInfo);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
+	export class SolarTermInfo {
+	    name: string;
+	    time: string;
+	    days: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new SolarTermInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.name = source["name"];
+	        this.time = source["time"];
+	        this.days = source["days"];
+	    }
+	}
+	export class WeekInfo {
+	    week: string;
+	    isoYear: number;
+	    isoWeek: number;
+	    weekOfMonth: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeekInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.week = source["week"];
+	        this.isoYear = source["isoYear"];
+	        this.isoWeek = source["isoWeek"];
+	        this.weekOfMonth = source["weekOfMonth"];
+	    }
+	}
+	export class WorkSegmentInfo {
+	    startAt: number;
+	    endAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkSegmentInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.startAt = source["startAt"];
+	        this.endAt = source["endAt"];
+	    }
+	}
+	export class WorkStatusInfo {
+	    phase: string;
+	    phaseName: string;
+	    window: string;
+	    shift: string;
+	    startAt: number;
+	    endAt: number;
+	    nextAt: number;
+	    seconds: number;
+	    progress: number;
+	    break: string;
+	    nextBreak: string;
+	    segments: WorkSegmentInfo[];
+	    nextShift: string;
+	    nextShiftAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WorkStatusInfo(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.phase = source["phase"];
+	        this.phaseName = source["phaseName"];
+	        this.window = source["window"];
+	        this.shift = source["shift"];
+	        this.startAt = source["startAt"];
+	        this.endAt = source["endAt"];
+	        this.nextAt = source["nextAt"];
+	        this.seconds = source["seconds"];
+	        this.progress = source["progress"];
+	        this.break = source["break"];
+	        this.nextBreak = source["nextBreak"];
+	        this.segments = this.convertValues(source["segments"], WorkSegmentInfo);
+	        this.nextShift = source["nextShift"];
+	        this.nextShiftAt = source["nextShiftAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 
 }
 
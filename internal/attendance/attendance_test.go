@@ -0,0 +1,246 @@
+package attendance_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"workoff-timer/internal/attendance"
+)
+
+var loc = time.FixedZone("CST", 8*3600)
+
+// TestStore 打卡、修改、备注及重新打开测试
+func TestStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "attendance.jsonl")
+	s, err := attendance.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(d, h, m int) time.Time { return time.Date(2026, 9, d, h, m, 0, 0, loc) }
+	if _, err := s.ClockOut(at(21, 18, 0)); !errors.Is(err, attendance.ErrNotClockedIn) {
+		t.Errorf("期望ErrNotClockedIn, 实际 %v", err)
+	}
+	in, err := s.ClockIn(at(21, 9, 12))
+	if err != nil || in.GetID() != 1 || in.GetKind() != attendance.ClockIn {
+		t.Fatalf("上班打卡失败: %v %v", in, err)
+	}
+	if _, err := s.ClockIn(at(21, 9, 13)); !errors.Is(err, attendance.ErrAlreadyClockedIn) {
+		t.Errorf("期望ErrAlreadyClockedIn, 实际 %v", err)
+	}
+	if _, err := s.ClockOut(at(21, 18, 30)); err != nil {
+		t.Fatal(err)
+	}
+	// 忘了打卡，补记9:00到岗
+	if r, err := s.Edit(1, at(21, 9, 0)); err != nil || !r.IsEdited() || !r.GetTime().Equal(at(21, 9, 0)) {
+		t.Errorf("修改失败: %v %v", r, err)
+	}
+	if _, err := s.Annotate(2, "加班赶版本"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Annotate(3, "不存在"); !errors.Is(err, attendance.ErrNotFound) {
+		t.Errorf("期望ErrNotFound, 实际 %v", err)
+	}
+	if _, err := s.ClockIn(at(22, 8, 50)); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+
+	// 重新打开后按顺序重放
+	s, err = attendance.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	r, err := s.Get(1)
+	if err != nil || !r.GetTime().Equal(at(21, 9, 0)) || !r.IsEdited() {
+		t.Errorf("期望修改后的9:00, 实际 %v %v", r, err)
+	}
+	if r, _ := s.Get(2); r.GetNote() != "加班赶版本" || r.GetKind() != attendance.ClockOut {
+		t.Errorf("期望备注, 实际 %v %q", r, r.GetNote())
+	}
+	if !s.IsClockedIn() {
+		t.Errorf("期望已上班打卡")
+	}
+	if records := s.Records(attendance.PeriodDay.Range(at(21, 12, 0))); len(records) != 2 {
+		t.Errorf("期望9月21日2条打卡, 实际 %v", records)
+	}
+}
+
+// TestCrashRecovery 写到一半崩溃留下的不完整末行测试
+func TestCrashRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "attendance.jsonl")
+	s, err := attendance.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ClockIn(time.Date(2026, 9, 21, 9, 0, 0, 0, loc)); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"op":"out","id":2,"at":"2026-09`)
+	f.Close()
+
+	s, err = attendance.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.IsClockedIn() {
+		t.Errorf("不完整的下班打卡应被丢弃")
+	}
+	if _, err := s.ClockOut(time.Date(2026, 9, 21, 18, 0, 0, 0, loc)); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	if s, err = attendance.Open(path); err != nil {
+		t.Fatalf("截掉不完整末行后应能再次打开: %v", err)
+	}
+	if s.IsClockedIn() {
+		t.Errorf("期望已下班打卡")
+	}
+	s.Close()
+
+	// 完整的末行只是缺少换行（如手工编辑过）时保留，并补上换行
+	os.WriteFile(path, []byte(`{"op":"in","id":1,"at":"2026-09-21T09:00:00+08:00"}
+{"op":"out","id":2,"at":"2026-09-21T18:00:00+08:00"}`), 0o644)
+	if s, err = attendance.Open(path); err != nil {
+		t.Fatal(err)
+	}
+	if s.IsClockedIn() {
+		t.Errorf("没有换行的下班打卡不应被丢弃")
+	}
+	if _, err := s.ClockIn(time.Date(2026, 9, 22, 9, 0, 0, 0, loc)); err != nil {
+		t.Fatal(err)
+	}
+	s.Close()
+	if s, err = attendance.Open(path); err != nil {
+		t.Fatalf("补上换行后应能再次打开: %v", err)
+	}
+	if r, err := s.Get(3); err != nil || r.GetKind() != attendance.ClockIn {
+		t.Errorf("期望第3条为上班打卡, 实际 %v %v", r, err)
+	}
+	s.Close()
+
+	// 中间行损坏时报错，不丢弃数据
+	os.WriteFile(path, []byte("{\"op\":\"in\"\n{}\n"), 0o644)
+	if _, err := attendance.Open(path); err == nil {
+		t.Errorf("损坏的文件应报错")
+	}
+
+	// 连续两次上班打卡（如手工编辑过）时报错，不会把下一次上班当成下班
+	os.WriteFile(path, []byte(`{"op":"in","id":1,"at":"2026-09-21T09:00:00+08:00"}
+{"op":"in","id":2,"at":"2026-09-22T09:00:00+08:00"}
+`), 0o644)
+	if _, err := attendance.Open(path); !errors.Is(err, attendance.ErrAlreadyClockedIn) {
+		t.Errorf("期望ErrAlreadyClockedIn, 实际 %v", err)
+	}
+}
+
+// TestSummarize 日、周、月汇总测试
+func TestSummarize(t *testing.T) {
+	s, err := attendance.Open(filepath.Join(t.TempDir(), "attendance.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	at := func(d, h, m int) time.Time { return time.Date(2026, 9, d, h, m, 0, 0, loc) }
+	// 9月21日（周一）两段，9月27日（周日）夜班跨零点，9月30日还没下班
+	for _, p := range [][2]time.Time{
+		{at(21, 9, 0), at(21, 12, 0)},
+		{at(21, 13, 0), at(21, 18, 0)},
+		{at(27, 22, 0), at(28, 6, 0)},
+		{at(30, 9, 0), {}},
+	} {
+		s.ClockIn(p[0])
+		if !p[1].IsZero() {
+			s.ClockOut(p[1])
+		}
+	}
+	now := at(30, 10, 30)
+	cases := []struct {
+		period attendance.Period
+		t      time.Time
+		days   int
+		worked time.Duration
+	}{
+		{attendance.PeriodDay, at(21, 20, 0), 1, 8 * time.Hour},
+		{attendance.PeriodDay, at(28, 3, 0), 0, 0},
+		{attendance.PeriodWeek, at(23, 0, 0), 2, 16 * time.Hour},
+		{attendance.PeriodWeek, at(30, 0, 0), 1, 90 * time.Minute},
+		{attendance.PeriodMonth, at(1, 0, 0), 3, 17*time.Hour + 30*time.Minute},
+	}
+	for _, c := range cases {
+		sum := s.Summarize(c.period, c.t)
+		if sum.GetDays() != c.days || sum.GetWorked(now) != c.worked {
+			t.Errorf("%s %s 期望 %d天 %s, 实际 %d天 %s", c.period, c.t, c.days, c.worked, sum.GetDays(), sum.GetWorked(now))
+		}
+	}
+	if sessions := s.Summarize(attendance.PeriodDay, now).GetSessions(); len(sessions) != 1 || !sessions[0].IsOpen() {
+		t.Errorf("期望今天一次未下班, 实际 %v", sessions)
+	}
+}
+
+// TestOrder 打卡和修改必须保持 上班 < 下班 < 下一次上班
+func TestOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "attendance.jsonl")
+	s, err := attendance.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(d, h, m int) time.Time { return time.Date(2026, 9, d, h, m, 0, 0, loc) }
+	s.ClockIn(at(21, 9, 0))
+	s.ClockOut(at(21, 18, 0))
+	s.ClockIn(at(22, 9, 0))
+
+	cases := []struct {
+		name  string
+		do    func() (attendance.Record, error)
+		after bool
+		id    int
+	}{
+		{"下班早于上班", func() (attendance.Record, error) { return s.ClockOut(at(22, 8, 0)) }, true, 3},
+		{"下班与上班同一时刻", func() (attendance.Record, error) { return s.ClockOut(at(22, 9, 0)) }, true, 3},
+		{"上班改到下班之后", func() (attendance.Record, error) { return s.Edit(1, at(21, 19, 0)) }, false, 2},
+		{"下班改到上班之前", func() (attendance.Record, error) { return s.Edit(2, at(21, 8, 0)) }, true, 1},
+		{"下班改到下一次上班之后", func() (attendance.Record, error) { return s.Edit(2, at(22, 10, 0)) }, false, 3},
+		{"上班改到前一天", func() (attendance.Record, error) { return s.Edit(3, at(21, 12, 0)) }, true, 2},
+	}
+	for _, c := range cases {
+		_, err := c.do()
+		var oe *attendance.OrderError
+		if !errors.Is(err, attendance.ErrOutOfOrder) || !errors.As(err, &oe) || oe.After != c.after || oe.Neighbor.GetID() != c.id {
+			t.Errorf("%s: 期望与第%d条冲突, 实际 %v", c.name, c.id, err)
+		}
+	}
+	if _, err := s.ClockOut(at(22, 18, 0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ClockIn(at(22, 17, 0)); !errors.Is(err, attendance.ErrOutOfOrder) {
+		t.Errorf("上班早于上一次下班: 期望ErrOutOfOrder, 实际 %v", err)
+	}
+	if _, err := s.Edit(2, at(21, 20, 0)); err != nil {
+		t.Errorf("前后顺序不变的修改应成功: %v", err)
+	}
+	s.Close()
+
+	// 之前写入的乱序记录仍能打开，上下班按上班时刻排列
+	os.WriteFile(path, []byte(`{"op":"in","id":1,"at":"2026-09-22T09:00:00+08:00"}
+{"op":"out","id":2,"at":"2026-09-22T18:00:00+08:00"}
+{"op":"in","id":3,"at":"2026-09-21T09:00:00+08:00"}
+{"op":"out","id":4,"at":"2026-09-21T18:00:00+08:00"}
+`), 0o644)
+	if s, err = attendance.Open(path); err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	sessions := s.Sessions(at(21, 0, 0), at(23, 0, 0))
+	if len(sessions) != 2 || sessions[0].GetIn().GetID() != 3 || sessions[1].GetIn().GetID() != 1 {
+		t.Errorf("期望按上班时刻排列, 实际 %v", sessions)
+	}
+}
@@ -0,0 +1,179 @@
+package attendance
+
+import (
+	"slices"
+	"time"
+)
+
+// ============ 打卡记录 ============
+
+// Kind 打卡类型
+type Kind int
+
+const (
+	ClockIn  Kind = iota // 上班打卡
+	ClockOut             // 下班打卡
+)
+
+// KindNames 打卡类型名称
+var KindNames = []string{"上班", "下班"}
+
+// String 获取名称
+func (k Kind) String() string {
+	return KindNames[k]
+}
+
+// Record 一次打卡，编号从1开始按打卡先后递增
+type Record struct {
+	id     int
+	kind   Kind
+	at     time.Time
+	note   string
+	edited bool
+}
+
+// GetID 获取编号
+func (o Record) GetID() int { return o.id }
+
+// GetKind 获取打卡类型
+func (o Record) GetKind() Kind { return o.kind }
+
+// GetTime 获取打卡时刻（修改后为修改的时刻）
+func (o Record) GetTime() time.Time { return o.at }
+
+// GetNote 获取备注
+func (o Record) GetNote() string { return o.note }
+
+// IsEdited 打卡时间是否修改过
+func (o Record) IsEdited() bool { return o.edited }
+
+// String 字符串表示，如“上班 2026-09-21 09:12”
+func (o Record) String() string {
+	return o.kind.String() + " " + o.at.Format("2006-01-02 15:04")
+}
+
+// ============ 上下班 ============
+
+// Session 一次上班到下班，还没下班打卡时out为nil
+type Session struct {
+	in  Record
+	out *Record
+}
+
+// GetIn 获取上班打卡
+func (o Session) GetIn() Record { return o.in }
+
+// GetOut 获取下班打卡，还没下班打卡时返回nil
+func (o Session) GetOut() *Record { return o.out }
+
+// IsOpen 是否还没下班打卡
+func (o Session) IsOpen() bool { return o.out == nil }
+
+// GetDuration 获取工作时长，还没下班打卡时算到now
+func (o Session) GetDuration(now time.Time) time.Duration {
+	end := now
+	if o.out != nil {
+		end = o.out.at
+	}
+	return max(0, end.Sub(o.in.at))
+}
+
+// Sessions 获取上班打卡在[from, to)之间的上下班，按上班时刻先后排列
+func (s *Store) Sessions(from time.Time, to time.Time) []Session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var sessions []Session
+	for i, r := range s.records {
+		if r.kind != ClockIn || r.at.Before(from) || !r.at.Before(to) {
+			continue
+		}
+		session := Session{in: r}
+		// 打开时已检查上下班交替，上班打卡的下一条就是对应的下班打卡
+		if i+1 < len(s.records) {
+			out := s.records[i+1]
+			session.out = &out
+		}
+		sessions = append(sessions, session)
+	}
+	// 打卡和修改都保证记录按时间先后编号，这里再排序是为了兼容之前写入的乱序记录
+	slices.SortStableFunc(sessions, func(a, b Session) int { return a.in.at.Compare(b.in.at) })
+	return sessions
+}
+
+// ============ 日、周、月 ============
+
+// Period 考勤统计的时间段
+type Period int
+
+const (
+	PeriodDay   Period = iota // 一天
+	PeriodWeek                // 一周（周一至周日）
+	PeriodMonth               // 一个月
+)
+
+// PeriodNames 时间段名称
+var PeriodNames = []string{"今天", "本周", "本月"}
+
+// String 获取名称
+func (p Period) String() string {
+	return PeriodNames[p]
+}
+
+// Range 获取t所在的日、周或月的[from, to)，按t所在时区计算
+func (p Period) Range(t time.Time) (time.Time, time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch p {
+	case PeriodWeek:
+		from := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return from, from.AddDate(0, 0, 7)
+	case PeriodMonth:
+		from := day.AddDate(0, 0, 1-day.Day())
+		return from, from.AddDate(0, 1, 0)
+	}
+	return day, day.AddDate(0, 0, 1)
+}
+
+// Summary 一段时间的考勤汇总
+type Summary struct {
+	period   Period
+	from     time.Time
+	to       time.Time
+	sessions []Session
+}
+
+// Summarize 获取t所在的日、周或月的考勤汇总，跨零点的上下班计入上班那天
+func (s *Store) Summarize(p Period, t time.Time) Summary {
+	from, to := p.Range(t)
+	return Summary{period: p, from: from, to: to, sessions: s.Sessions(from, to)}
+}
+
+// GetPeriod 获取时间段
+func (o Summary) GetPeriod() Period { return o.period }
+
+// GetFrom 获取开始时刻
+func (o Summary) GetFrom() time.Time { return o.from }
+
+// GetTo 获取结束时刻（不含）
+func (o Summary) GetTo() time.Time { return o.to }
+
+// GetSessions 获取上下班
+func (o Summary) GetSessions() []Session { return o.sessions }
+
+// GetWorked 获取工作总时长，还没下班打卡的算到now
+func (o Summary) GetWorked(now time.Time) time.Duration {
+	var d time.Duration
+	for _, s := range o.sessions {
+		d += s.GetDuration(now)
+	}
+	return d
+}
+
+// GetDays 获取有上班打卡的天数
+func (o Summary) GetDays() int {
+	days := map[time.Time]bool{}
+	for _, s := range o.sessions {
+		t := s.in.at.In(o.from.Location())
+		days[time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())] = true
+	}
+	return len(days)
+}
@@ -0,0 +1,319 @@
+package attendance
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// ============ 考勤记录文件 ============
+
+// 考勤记录保存为JSON Lines，每行一个事件，只追加不修改：打卡、修改时间、备注都追加一行，读取时按顺序重放
+// 每次写入后fsync；写到一半崩溃留下的不完整末行在下次打开时截掉
+
+var (
+	// ErrNotFound 打卡记录不存在
+	ErrNotFound = errors.New("打卡记录不存在")
+	// ErrAlreadyClockedIn 已上班打卡，需先下班打卡
+	ErrAlreadyClockedIn = errors.New("已上班打卡")
+	// ErrNotClockedIn 还没有上班打卡
+	ErrNotClockedIn = errors.New("还没有上班打卡")
+	// ErrOutOfOrder 打卡时间与前后记录的先后顺序不符，详见 OrderError
+	ErrOutOfOrder = errors.New("打卡时间顺序不对")
+)
+
+// OrderError 打卡时间与前后记录的先后顺序不符：每条记录必须晚于前一条、早于后一条，即上班 < 下班 < 下一次上班
+type OrderError struct {
+	At time.Time
+	// Neighbor 冲突的相邻记录，After为true时At应晚于它（前一条），否则应早于它（后一条）
+	Neighbor Record
+	After    bool
+}
+
+func (e *OrderError) Error() string {
+	relation := "早于"
+	if e.After {
+		relation = "晚于"
+	}
+	return fmt.Sprintf("%v: %s应%s%s", ErrOutOfOrder, e.At.Format("2006-01-02 15:04"), relation, e.Neighbor)
+}
+
+func (e *OrderError) Unwrap() error {
+	return ErrOutOfOrder
+}
+
+// event 文件中的一行
+type event struct {
+	Op   string     `json:"op"`
+	ID   int        `json:"id"`
+	At   *time.Time `json:"at,omitempty"`
+	Note string     `json:"note,omitempty"`
+	// Logged 写入时刻，用于追溯修改
+	Logged time.Time `json:"logged"`
+}
+
+// 事件类型
+const (
+	opClockIn  = "in"
+	opClockOut = "out"
+	opEdit     = "edit"
+	opNote     = "note"
+)
+
+// Store 考勤记录，并发安全
+type Store struct {
+	mu      sync.Mutex
+	file    *os.File
+	records []Record
+	// size 文件中完整事件的长度，写入失败时截回这里，避免留下半行
+	size int64
+	now  func() time.Time
+}
+
+// Open 打开考勤记录文件，不存在时创建
+func Open(path string) (*Store, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	s := &Store{file: f, now: time.Now}
+	if err := s.load(); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return s, nil
+}
+
+// Close 关闭文件
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+// load 读取并重放全部事件；末行没有换行时，能解析的（如手工编辑过）补上换行，不能解析的（写到一半崩溃）截掉
+func (s *Store) load() error {
+	data, err := io.ReadAll(s.file)
+	if err != nil {
+		return err
+	}
+	valid := bytes.LastIndexByte(data, '\n') + 1
+	sc := bufio.NewScanner(bytes.NewReader(data[:valid]))
+	sc.Buffer(nil, len(data)+1)
+	line := 1
+	for ; sc.Scan(); line++ {
+		if len(bytes.TrimSpace(sc.Bytes())) == 0 {
+			continue
+		}
+		var e event
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return fmt.Errorf("第%d行: %w", line, err)
+		}
+		if err := s.apply(e); err != nil {
+			return fmt.Errorf("第%d行: %w", line, err)
+		}
+	}
+	var e event
+	if tail := data[valid:]; len(bytes.TrimSpace(tail)) > 0 && json.Unmarshal(tail, &e) == nil {
+		if err := s.apply(e); err != nil {
+			return fmt.Errorf("第%d行: %w", line, err)
+		}
+		if _, err := s.file.WriteAt([]byte{'\n'}, int64(len(data))); err != nil {
+			return err
+		}
+		valid = len(data) + 1
+	} else if valid < len(data) {
+		if err := s.file.Truncate(int64(valid)); err != nil {
+			return err
+		}
+	}
+	s.size = int64(valid)
+	_, err = s.file.Seek(s.size, io.SeekStart)
+	return err
+}
+
+// apply 把事件应用到内存中的记录，检查编号连续、上下班交替
+func (s *Store) apply(e event) error {
+	switch e.Op {
+	case opClockIn, opClockOut:
+		if e.ID != len(s.records)+1 {
+			return fmt.Errorf("编号不连续: %d", e.ID)
+		}
+		kind := ClockIn
+		if e.Op == opClockOut {
+			kind = ClockOut
+		}
+		// 上下班必须交替，Sessions 按相邻两条配对
+		if kind == ClockIn && s.clockedIn() {
+			return fmt.Errorf("%w: %d", ErrAlreadyClockedIn, e.ID)
+		}
+		if kind == ClockOut && !s.clockedIn() {
+			return fmt.Errorf("%w: %d", ErrNotClockedIn, e.ID)
+		}
+		if e.At == nil {
+			return fmt.Errorf("缺少打卡时间: %d", e.ID)
+		}
+		s.records = append(s.records, Record{id: e.ID, kind: kind, at: *e.At, note: e.Note})
+	case opEdit, opNote:
+		if e.ID < 1 || e.ID > len(s.records) {
+			return fmt.Errorf("%w: %d", ErrNotFound, e.ID)
+		}
+		r := &s.records[e.ID-1]
+		if e.Op == opEdit {
+			if e.At == nil {
+				return fmt.Errorf("缺少打卡时间: %d", e.ID)
+			}
+			r.at, r.edited = *e.At, true
+		} else {
+			r.note = e.Note
+		}
+	default:
+		return fmt.Errorf("未知事件: %q", e.Op)
+	}
+	return nil
+}
+
+// append 写入一行事件并fsync，成功后再应用到内存
+func (s *Store) append(e event) error {
+	e.Logged = s.now()
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if _, err := s.file.Write(data); err != nil {
+		return errors.Join(err, s.rollback())
+	}
+	if err := s.file.Sync(); err != nil {
+		return errors.Join(err, s.rollback())
+	}
+	s.size += int64(len(data))
+	return s.apply(e)
+}
+
+// rollback 截掉写入失败的半行
+func (s *Store) rollback() error {
+	if err := s.file.Truncate(s.size); err != nil {
+		return err
+	}
+	_, err := s.file.Seek(s.size, io.SeekStart)
+	return err
+}
+
+// ============ 打卡 ============
+
+// ClockIn 上班打卡，已上班打卡还没下班打卡时返回 ErrAlreadyClockedIn，不晚于上一条记录时返回 *OrderError
+func (s *Store) ClockIn(at time.Time) (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.clockedIn() {
+		return Record{}, ErrAlreadyClockedIn
+	}
+	if err := s.checkOrder(len(s.records)+1, at); err != nil {
+		return Record{}, err
+	}
+	if err := s.append(event{Op: opClockIn, ID: len(s.records) + 1, At: &at}); err != nil {
+		return Record{}, err
+	}
+	return s.records[len(s.records)-1], nil
+}
+
+// ClockOut 下班打卡，还没有上班打卡时返回 ErrNotClockedIn，不晚于上班打卡时返回 *OrderError
+func (s *Store) ClockOut(at time.Time) (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.clockedIn() {
+		return Record{}, ErrNotClockedIn
+	}
+	if err := s.checkOrder(len(s.records)+1, at); err != nil {
+		return Record{}, err
+	}
+	if err := s.append(event{Op: opClockOut, ID: len(s.records) + 1, At: &at}); err != nil {
+		return Record{}, err
+	}
+	return s.records[len(s.records)-1], nil
+}
+
+// IsClockedIn 是否已上班打卡还没下班打卡
+func (s *Store) IsClockedIn() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clockedIn()
+}
+
+// clockedIn 最后一条记录是否为上班打卡
+func (s *Store) clockedIn() bool {
+	return len(s.records) > 0 && s.records[len(s.records)-1].kind == ClockIn
+}
+
+// checkOrder 检查编号为id的记录（可以是下一条新记录）改为at后是否仍晚于前一条、早于后一条
+func (s *Store) checkOrder(id int, at time.Time) error {
+	if id > 1 && !at.After(s.records[id-2].at) {
+		return &OrderError{At: at, Neighbor: s.records[id-2], After: true}
+	}
+	if id < len(s.records) && !at.Before(s.records[id].at) {
+		return &OrderError{At: at, Neighbor: s.records[id]}
+	}
+	return nil
+}
+
+// Edit 修改打卡时间（如忘了打卡后补记），原记录保留在文件中
+// 修改后必须仍晚于前一条、早于后一条记录，否则返回 *OrderError
+func (s *Store) Edit(id int, at time.Time) (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id < 1 || id > len(s.records) {
+		return Record{}, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	if err := s.checkOrder(id, at); err != nil {
+		return Record{}, err
+	}
+	if err := s.append(event{Op: opEdit, ID: id, At: &at}); err != nil {
+		return Record{}, err
+	}
+	return s.records[id-1], nil
+}
+
+// Annotate 给打卡记录加备注（如“外出拜访客户”），传空字符串清除备注
+func (s *Store) Annotate(id int, note string) (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id < 1 || id > len(s.records) {
+		return Record{}, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	if err := s.append(event{Op: opNote, ID: id, Note: note}); err != nil {
+		return Record{}, err
+	}
+	return s.records[id-1], nil
+}
+
+// Get 获取打卡记录
+func (s *Store) Get(id int) (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id < 1 || id > len(s.records) {
+		return Record{}, fmt.Errorf("%w: %d", ErrNotFound, id)
+	}
+	return s.records[id-1], nil
+}
+
+// Records 获取[from, to)之间的打卡记录，按时间先后排列
+func (s *Store) Records(from time.Time, to time.Time) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var records []Record
+	for _, r := range s.records {
+		if !r.at.Before(from) && r.at.Before(to) {
+			records = append(records, r)
+		}
+	}
+	slices.SortStableFunc(records, func(a, b Record) int { return a.at.Compare(b.at) })
+	return records
+}
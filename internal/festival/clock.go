@@ -0,0 +1,88 @@
+package festival
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ============ 时钟 ============
+
+// Clock 时钟，获取当前时刻，便于测试和演示指定时刻的效果
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock 系统时钟
+type SystemClock struct{}
+
+// Now 获取当前时刻
+func (SystemClock) Now() time.Time { return time.Now() }
+
+// FixedClock 固定时钟，始终返回同一时刻
+type FixedClock struct {
+	t time.Time
+}
+
+// NewFixedClock 创建固定时钟
+func NewFixedClock(t time.Time) FixedClock {
+	return FixedClock{t: t}
+}
+
+// Now 获取当前时刻
+func (o FixedClock) Now() time.Time { return o.t }
+
+// OffsetClock 偏移时钟，在另一个时钟的基础上加一段固定偏移，时间照常流逝
+type OffsetClock struct {
+	base   Clock
+	offset time.Duration
+}
+
+// NewOffsetClock 创建偏移时钟，base为nil时使用系统时钟
+func NewOffsetClock(base Clock, offset time.Duration) OffsetClock {
+	if base == nil {
+		base = SystemClock{}
+	}
+	return OffsetClock{base: base, offset: offset}
+}
+
+// NewOffsetClockAt 创建偏移时钟，使其当前时刻为at，之后照常流逝
+func NewOffsetClockAt(base Clock, at time.Time) OffsetClock {
+	o := NewOffsetClock(base, 0)
+	o.offset = at.Sub(o.base.Now())
+	return o
+}
+
+// Now 获取当前时刻
+func (o OffsetClock) Now() time.Time { return o.base.Now().Add(o.offset) }
+
+// GetOffset 获取偏移
+func (o OffsetClock) GetOffset() time.Duration { return o.offset }
+
+// clockHolder 包一层以便atomic.Pointer存放接口
+type clockHolder struct {
+	clock Clock
+}
+
+var currentClock atomic.Pointer[clockHolder]
+
+func init() {
+	currentClock.Store(&clockHolder{clock: SystemClock{}})
+}
+
+// CurrentClock 获取包默认时钟，Today、TodayIn使用该时钟
+func CurrentClock() Clock {
+	return currentClock.Load().clock
+}
+
+// SetClock 设置包默认时钟，传nil恢复为系统时钟
+func SetClock(c Clock) {
+	if c == nil {
+		c = SystemClock{}
+	}
+	currentClock.Store(&clockHolder{clock: c})
+}
+
+// TodayFrom 根据指定时钟获取日历参考时区的今天（含时分秒）
+func TodayFrom(c Clock) SolarDay {
+	return NewSolarDayFromTime(c.Now().In(CalendarLocation()))
+}
@@ -0,0 +1,638 @@
+package festival
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+)
+
+// ============ 节日数据集 ============
+
+// DatasetVersion 当前支持的节日数据文件版本
+const DatasetVersion = 1
+
+// 节日所用历法
+const (
+	// CalendarSolar 公历
+	CalendarSolar = "solar"
+	// CalendarLunar 农历
+	CalendarLunar = "lunar"
+)
+
+//go:embed festivals.json
+var defaultDatasetData []byte
+
+// FestivalRule 节日规则，以下几种写法任选其一
+//   - 固定日期: month + day，day为-1表示当月最后一天
+//   - 第N个星期几（仅公历）: month + week + weekday，week为-1表示最后一个，weekday为0表示星期日
+//   - 节气当天（仅农历）: term，如“清明”
+type FestivalRule struct {
+	Month   int    `json:"month,omitempty"`
+	Day     int    `json:"day,omitempty"`
+	Week    int    `json:"week,omitempty"`
+	Weekday *int   `json:"weekday,omitempty"`
+	Term    string `json:"term,omitempty"`
+}
+
+// FestivalDefinition 节日定义（数据文件中的一条记录）
+type FestivalDefinition struct {
+	Name      string       `json:"name"`
+	Calendar  string       `json:"calendar"`
+	Rule      FestivalRule `json:"rule"`
+	StartYear int          `json:"startYear,omitempty"`
+	EndYear   int          `json:"endYear,omitempty"`
+	Type      string       `json:"type,omitempty"`
+	Tags      []string     `json:"tags,omitempty"`
+}
+
+// festivalTypeNames 数据文件中type字段的取值
+var festivalTypeNames = map[string]FestivalTypeEnum{
+	"solar":     FestivalTypeSolar,
+	"lunar":     FestivalTypeLunar,
+	"solarTerm": FestivalTypeSolarTerm,
+	"season":    FestivalTypeSeason,
+}
+
+// DatasetError 节日数据文件错误，带出错位置
+type DatasetError struct {
+	Source string
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *DatasetError) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %v", e.Source, e.Line, e.Column, e.Err)
+}
+
+func (e *DatasetError) Unwrap() error {
+	return e.Err
+}
+
+// Dataset 节日数据集
+type Dataset struct {
+	solar []festivalDefinition
+	lunar []festivalDefinition
+	// 查找表，值为节日在solar、lunar中的下标（升序，即数据集中的顺序）
+	solarByDate  map[monthDay][]int
+	solarByMonth map[int][]int
+	lunarByDate  map[monthDay][]int
+	lunarByMonth map[int][]int
+	lunarByTerm  map[int][]int
+}
+
+// monthDay 月日，查找表的键
+type monthDay struct {
+	month int
+	day   int
+}
+
+// newDataset 根据校验后的节日定义创建数据集，并建立按月日、按月、按节气的查找表
+func newDataset(solar []festivalDefinition, lunar []festivalDefinition) *Dataset {
+	d := &Dataset{
+		solar:        solar,
+		lunar:        lunar,
+		solarByDate:  map[monthDay][]int{},
+		solarByMonth: map[int][]int{},
+		lunarByDate:  map[monthDay][]int{},
+		lunarByMonth: map[int][]int{},
+		lunarByTerm:  map[int][]int{},
+	}
+	for i, def := range solar {
+		// 固定日期按月日查，月末和星期规则按月查
+		if def.Rule.Day > 0 {
+			key := monthDay{def.Rule.Month, def.Rule.Day}
+			d.solarByDate[key] = append(d.solarByDate[key], i)
+		} else {
+			d.solarByMonth[def.Rule.Month] = append(d.solarByMonth[def.Rule.Month], i)
+		}
+	}
+	for i, def := range lunar {
+		switch {
+		case def.term >= 0:
+			d.lunarByTerm[def.term] = append(d.lunarByTerm[def.term], i)
+		case def.Rule.Day > 0:
+			key := monthDay{def.Rule.Month, def.Rule.Day}
+			d.lunarByDate[key] = append(d.lunarByDate[key], i)
+		default:
+			d.lunarByMonth[def.Rule.Month] = append(d.lunarByMonth[def.Rule.Month], i)
+		}
+	}
+	return d
+}
+
+// solarFestivals 获取公历日的全部节日在solar中的下标
+func (d *Dataset) solarFestivals(day SolarDay) []int {
+	var result []int
+	for _, i := range mergeIndexes(d.solarByDate[monthDay{day.month, day.day}], d.solarByMonth[day.month]) {
+		if d.solar[i].matchSolar(day) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// lunarFestivals 获取农历日的全部节日在lunar中的下标，month为负数表示闰月，term为当天节气索引(0-23)，不是节气时为-1
+func (d *Dataset) lunarFestivals(year int, month int, day int, term int) []int {
+	candidates := mergeIndexes(d.lunarByDate[monthDay{month, day}], d.lunarByMonth[abs(month)])
+	if term >= 0 {
+		candidates = mergeIndexes(candidates, d.lunarByTerm[term])
+	}
+	var result []int
+	for _, i := range candidates {
+		if d.lunar[i].matchLunar(year, month, day, term) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// mergeIndexes 合并两个升序下标列表
+func mergeIndexes(a []int, b []int) []int {
+	if len(b) == 0 {
+		return a
+	}
+	if len(a) == 0 {
+		return b
+	}
+	result := make([]int, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0] < b[0] {
+			result, a = append(result, a[0]), a[1:]
+		} else {
+			result, b = append(result, b[0]), b[1:]
+		}
+	}
+	return append(append(result, a...), b...)
+}
+
+// festivalDefinition 校验后的节日定义
+type festivalDefinition struct {
+	FestivalDefinition
+	festivalType FestivalTypeEnum
+	term         int
+}
+
+// LoadDataset 从JSON读取节日数据集，source为出错时显示的来源名称（通常是文件名）
+func LoadDataset(source string, r io.Reader) (*Dataset, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := newDatasetParser(source, data)
+	var solar, lunar []festivalDefinition
+	err = p.parse(map[string]func(json.RawMessage, int64) error{
+		"festivals": func(raw json.RawMessage, base int64) error {
+			def, err := p.parseFestival(raw, base)
+			if err != nil {
+				return err
+			}
+			if def.Calendar == CalendarSolar {
+				solar = append(solar, def)
+			} else {
+				lunar = append(lunar, def)
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newDataset(solar, lunar), nil
+}
+
+// DefaultDataset 获取内置节日数据集
+func DefaultDataset() *Dataset {
+	return defaultDataset
+}
+
+var defaultDataset = mustLoadDefaultDataset()
+
+var currentDataset atomic.Pointer[Dataset]
+
+func init() {
+	currentDataset.Store(defaultDataset)
+}
+
+// mustLoadDefaultDataset 读取内置节日数据集，内置数据有误时panic
+func mustLoadDefaultDataset() *Dataset {
+	d, err := LoadDataset("festivals.json", bytes.NewReader(defaultDatasetData))
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// CurrentDataset 获取当前使用的节日数据集
+func CurrentDataset() *Dataset {
+	return currentDataset.Load()
+}
+
+// SetDataset 设置当前使用的节日数据集，传nil恢复为内置数据集
+func SetDataset(d *Dataset) {
+	if d == nil {
+		d = defaultDataset
+	}
+	currentDataset.Store(d)
+	resetYearIndexes()
+}
+
+// Merge 合并两个数据集，返回新数据集，other中的节日排在后面
+func (d *Dataset) Merge(other *Dataset) *Dataset {
+	return newDataset(
+		append(append([]festivalDefinition(nil), d.solar...), other.solar...),
+		append(append([]festivalDefinition(nil), d.lunar...), other.lunar...),
+	)
+}
+
+// GetDefinitions 获取全部节日定义
+func (d *Dataset) GetDefinitions() []FestivalDefinition {
+	defs := make([]FestivalDefinition, 0, len(d.solar)+len(d.lunar))
+	for _, def := range d.solar {
+		defs = append(defs, def.FestivalDefinition)
+	}
+	for _, def := range d.lunar {
+		defs = append(defs, def.FestivalDefinition)
+	}
+	return defs
+}
+
+// inYears 年份是否在节日的起止年份内
+func (o festivalDefinition) inYears(year int) bool {
+	if o.StartYear != 0 && year < o.StartYear {
+		return false
+	}
+	if o.EndYear != 0 && year > o.EndYear {
+		return false
+	}
+	return true
+}
+
+// matchSolar 是否为该公历节日
+func (o festivalDefinition) matchSolar(d SolarDay) bool {
+	r := o.Rule
+	if !o.inYears(d.year) || r.Month != d.month {
+		return false
+	}
+	monthDays := GetSolarMonthDays(d.year, d.month)
+	switch {
+	case r.Day > 0:
+		return d.day == r.Day
+	case r.Day == -1:
+		return d.day == monthDays
+	case r.Weekday == nil || d.GetWeek().GetIndex() != *r.Weekday:
+		return false
+	case r.Week > 0:
+		return (d.day-1)/7+1 == r.Week
+	default:
+		return d.day+7 > monthDays
+	}
+}
+
+// matchLunar 是否为该农历节日，month为负数表示闰月，term为当天节气索引(0-23)，不是节气时为-1
+func (o festivalDefinition) matchLunar(year int, month int, day int, term int) bool {
+	if !o.inYears(year) {
+		return false
+	}
+	r := o.Rule
+	if o.term >= 0 {
+		return o.term == term
+	}
+	if r.Day > 0 {
+		// 固定日期不在闰月重复
+		return r.Month == month && r.Day == day
+	}
+	// 当月最后一天，有同名闰月时以闰月最后一天为准
+	if abs(month) != r.Month {
+		return false
+	}
+	m, err := NewLunarMonth(year, month)
+	if err != nil || day != m.GetDayCount() {
+		return false
+	}
+	return m.Next(1).GetMonth() != r.Month
+}
+
+// ============ 旧版节日表 ============
+
+// legacySolarNames 生成旧版公历节日名称表
+func (d *Dataset) legacySolarNames() []string {
+	names := make([]string, len(d.solar))
+	for i, def := range d.solar {
+		names[i] = def.Name
+	}
+	return names
+}
+
+// legacySolarData 生成旧版公历节日数据，月末规则旧格式无法表示，跳过
+func (d *Dataset) legacySolarData() string {
+	var b strings.Builder
+	for i, def := range d.solar {
+		r := def.Rule
+		switch {
+		case r.Day > 0:
+			fmt.Fprintf(&b, "@%02d0%02d%02d", i, r.Month, r.Day)
+		case r.Weekday != nil && r.Week > 0:
+			fmt.Fprintf(&b, "@%02d1%02d%d%d", i, r.Month, r.Week, *r.Weekday)
+		case r.Weekday != nil:
+			fmt.Fprintf(&b, "@%02d2%02d%d", i, r.Month, *r.Weekday)
+		default:
+			continue
+		}
+		fmt.Fprintf(&b, "%04d", def.StartYear)
+		if def.EndYear != 0 {
+			fmt.Fprintf(&b, "%04d", def.EndYear)
+		}
+	}
+	return b.String()
+}
+
+// legacyLunarNames 生成旧版农历节日名称表
+func (d *Dataset) legacyLunarNames() []string {
+	names := make([]string, len(d.lunar))
+	for i, def := range d.lunar {
+		names[i] = def.Name
+	}
+	return names
+}
+
+// legacyLunarData 生成旧版农历节日数据，除腊月最后一天外的月末规则旧格式无法表示，跳过
+func (d *Dataset) legacyLunarData() string {
+	var b strings.Builder
+	for i, def := range d.lunar {
+		r := def.Rule
+		switch {
+		case def.term >= 0:
+			// 旧格式的节气索引相对农历年，冬至、小寒、大寒在农历年末
+			term := def.term
+			if term < 3 {
+				term += 24
+			}
+			fmt.Fprintf(&b, "@%02d1%02d", i, term)
+		case r.Day > 0:
+			fmt.Fprintf(&b, "@%02d0%02d%02d", i, r.Month, r.Day)
+		case r.Month == 12:
+			fmt.Fprintf(&b, "@%02d2", i)
+		}
+	}
+	return b.String()
+}
+
+// ============ 数据文件解析 ============
+
+// datasetParser 节日数据文件解析器，记录每条记录的位置以便报告行号
+type datasetParser struct {
+	source string
+	data   []byte
+	dec    *json.Decoder
+}
+
+func newDatasetParser(source string, data []byte) *datasetParser {
+	return &datasetParser{source: source, data: data, dec: json.NewDecoder(bytes.NewReader(data))}
+}
+
+// errorAt 生成指定偏移处的错误
+func (p *datasetParser) errorAt(offset int64, err error) error {
+	line, column := 1, 1
+	// 列号按字符计，与编辑器显示一致
+	for _, c := range string(p.data[:min(int(offset), len(p.data))]) {
+		if c == '\n' {
+			line++
+			column = 1
+		} else {
+			column++
+		}
+	}
+	return &DatasetError{Source: p.source, Line: line, Column: column, Err: err}
+}
+
+// jsonError 把encoding/json的错误转换为带位置的错误
+func (p *datasetParser) jsonError(base int64, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		return p.errorAt(base+syntaxErr.Offset, err)
+	case errors.As(err, &typeErr):
+		return p.errorAt(base+typeErr.Offset, err)
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		return p.errorAt(int64(len(p.data)), fmt.Errorf("文件不完整"))
+	default:
+		return p.errorAt(base, err)
+	}
+}
+
+// skipSpace 跳过空白和逗号，返回下一个值的起始偏移
+func (p *datasetParser) skipSpace(offset int64) int64 {
+	for offset < int64(len(p.data)) {
+		switch p.data[offset] {
+		case ' ', '\t', '\r', '\n', ',', ':':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// expectDelim 读取指定的分隔符
+func (p *datasetParser) expectDelim(delim json.Delim) error {
+	offset := p.skipSpace(p.dec.InputOffset())
+	t, err := p.dec.Token()
+	if err != nil {
+		return p.jsonError(0, err)
+	}
+	if d, ok := t.(json.Delim); !ok || d != delim {
+		return p.errorAt(offset, fmt.Errorf("期望 %s", delim))
+	}
+	return nil
+}
+
+// parse 解析带版本号的数据文件，arrays为各数组字段中每个元素的处理函数，base为元素在文件中的偏移
+func (p *datasetParser) parse(arrays map[string]func(raw json.RawMessage, base int64) error) error {
+	if err := p.expectDelim('{'); err != nil {
+		return err
+	}
+	version := 0
+	hasVersion := false
+	for p.dec.More() {
+		offset := p.skipSpace(p.dec.InputOffset())
+		t, err := p.dec.Token()
+		if err != nil {
+			return p.jsonError(0, err)
+		}
+		key, _ := t.(string)
+		if key == "version" {
+			offset = p.skipSpace(p.dec.InputOffset())
+			if err := p.dec.Decode(&version); err != nil {
+				return p.jsonError(0, err)
+			}
+			if version != DatasetVersion {
+				return p.errorAt(offset, fmt.Errorf("不支持的版本: %d，当前支持版本 %d", version, DatasetVersion))
+			}
+			hasVersion = true
+			continue
+		}
+		element, ok := arrays[key]
+		if !ok {
+			return p.errorAt(offset, fmt.Errorf("未知字段: %v", t))
+		}
+		if err := p.parseArray(element); err != nil {
+			return err
+		}
+	}
+	if err := p.expectDelim('}'); err != nil {
+		return err
+	}
+	if !hasVersion {
+		return p.errorAt(0, fmt.Errorf("缺少version字段"))
+	}
+	return nil
+}
+
+// parseArray 逐个读取数组元素
+func (p *datasetParser) parseArray(element func(raw json.RawMessage, base int64) error) error {
+	if err := p.expectDelim('['); err != nil {
+		return err
+	}
+	for p.dec.More() {
+		base := p.skipSpace(p.dec.InputOffset())
+		var raw json.RawMessage
+		if err := p.dec.Decode(&raw); err != nil {
+			return p.jsonError(0, err)
+		}
+		if err := element(raw, base); err != nil {
+			return err
+		}
+	}
+	return p.expectDelim(']')
+}
+
+// decodeElement 严格解析数组元素，不允许未知字段
+func (p *datasetParser) decodeElement(raw json.RawMessage, base int64, v any) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return p.jsonError(base, err)
+	}
+	return nil
+}
+
+// parseFestival 解析一条节日定义
+func (p *datasetParser) parseFestival(raw json.RawMessage, base int64) (festivalDefinition, error) {
+	var def FestivalDefinition
+	if err := p.decodeElement(raw, base, &def); err != nil {
+		return festivalDefinition{}, err
+	}
+	compiled, field, err := compileDefinition(def)
+	if err != nil {
+		return festivalDefinition{}, p.errorAt(base+fieldOffset(raw, field), fmt.Errorf("%s: %w", def.Name, err))
+	}
+	return compiled, nil
+}
+
+// compileDefinition 校验节日定义，出错时返回出错的字段路径
+func compileDefinition(def FestivalDefinition) (festivalDefinition, []string, error) {
+	o := festivalDefinition{FestivalDefinition: def, term: -1}
+	r := def.Rule
+	if def.Name == "" {
+		return o, []string{"name"}, fmt.Errorf("缺少节日名称")
+	}
+	switch def.Calendar {
+	case CalendarSolar:
+		o.festivalType = FestivalTypeSolar
+	case CalendarLunar:
+		o.festivalType = FestivalTypeLunar
+	default:
+		return o, []string{"calendar"}, fmt.Errorf("非法历法: %q，应为%q或%q", def.Calendar, CalendarSolar, CalendarLunar)
+	}
+	if def.Type != "" {
+		t, ok := festivalTypeNames[def.Type]
+		if !ok {
+			return o, []string{"type"}, fmt.Errorf("非法节日类型: %q", def.Type)
+		}
+		o.festivalType = t
+	}
+	if def.EndYear != 0 && def.EndYear < def.StartYear {
+		return o, []string{"endYear"}, fmt.Errorf("结束年份%d早于起始年份%d", def.EndYear, def.StartYear)
+	}
+
+	if r.Term != "" {
+		if def.Calendar != CalendarLunar {
+			return o, []string{"rule", "term"}, fmt.Errorf("只有农历节日支持节气规则")
+		}
+		if r.Month != 0 || r.Day != 0 || r.Week != 0 || r.Weekday != nil {
+			return o, []string{"rule", "term"}, fmt.Errorf("节气规则不能同时指定月日")
+		}
+		for i, name := range SolarTermNames {
+			if name == r.Term {
+				o.term = i
+				return o, nil, nil
+			}
+		}
+		return o, []string{"rule", "term"}, fmt.Errorf("非法节气: %q", r.Term)
+	}
+
+	if r.Month < 1 || r.Month > 12 {
+		return o, []string{"rule", "month"}, fmt.Errorf("非法月份: %d", r.Month)
+	}
+	if r.Week != 0 || r.Weekday != nil {
+		if def.Calendar != CalendarSolar {
+			return o, []string{"rule", "week"}, fmt.Errorf("只有公历节日支持星期规则")
+		}
+		if r.Day != 0 {
+			return o, []string{"rule", "day"}, fmt.Errorf("星期规则不能同时指定日期")
+		}
+		if r.Week == 0 || r.Week < -1 || r.Week > 5 {
+			return o, []string{"rule", "week"}, fmt.Errorf("非法周次: %d，应为1-5或-1", r.Week)
+		}
+		if r.Weekday == nil || *r.Weekday < 0 || *r.Weekday > 6 {
+			return o, []string{"rule", "weekday"}, fmt.Errorf("缺少或非法星期，应为0(星期日)-6")
+		}
+		return o, nil, nil
+	}
+	maxDay := 30
+	if def.Calendar == CalendarSolar {
+		maxDay = GetSolarMonthDays(2000, r.Month)
+	}
+	if r.Day != -1 && (r.Day < 1 || r.Day > maxDay) {
+		return o, []string{"rule", "day"}, fmt.Errorf("非法日期: %d月%d日", r.Month, r.Day)
+	}
+	return o, nil, nil
+}
+
+// fieldOffset 查找JSON对象中字段的偏移，path为字段路径，找不到时返回0（对象起始处）
+func fieldOffset(raw []byte, path []string) int64 {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	var found int64
+	for depth := 0; depth < len(path); depth++ {
+		if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+			return found
+		}
+		for {
+			start := dec.InputOffset()
+			t, err := dec.Token()
+			if err != nil || t == json.Delim('}') {
+				return found
+			}
+			if t == path[depth] {
+				// 跳过键前的逗号和空白
+				for start < int64(len(raw)) && raw[start] != '"' {
+					start++
+				}
+				found = start
+				break
+			}
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return found
+			}
+		}
+	}
+	return found
+}
@@ -0,0 +1,66 @@
+package festival
+
+// 仅供测试：不使用年度索引的计算方法，用于验证索引结果、对比性能
+
+// ResetYearIndexes 清空年度索引
+var ResetYearIndexes = resetYearIndexes
+
+// ComputeLunarDay 不使用年度索引计算农历日
+func ComputeLunarDay(o SolarDay) (LunarDay, error) {
+	return o.computeLunarDay()
+}
+
+// ComputeFestivals 不使用年度索引计算当天节日（含时令），即建立索引前的逐日算法
+func ComputeFestivals(o SolarDay) []Festival {
+	var festivals []Festival
+	ld, _ := o.computeLunarDay()
+	for _, def := range CurrentDataset().lunar {
+		match := false
+		if def.term < 0 {
+			match = def.matchLunar(ld.GetYear(), ld.GetMonth(), ld.GetDay(), -1)
+		} else if def.inYears(ld.GetYear()) {
+			for _, index := range []int{def.term, def.term + 24} {
+				d, _ := NewSolarTermFromIndex(ld.GetYear(), index).GetSolarDay().computeLunarDay()
+				match = match || (d.GetYear() == ld.GetYear() && d.GetMonth() == ld.GetMonth() && d.GetDay() == ld.GetDay())
+			}
+		}
+		if match {
+			festivals = append(festivals, Festival{Type: def.festivalType, Name: def.Name, SolarDay: o, Tags: def.Tags})
+		}
+	}
+	// 同一天的公历节日全部保留，逐个检查而不用按日期的查找表
+	for _, def := range CurrentDataset().solar {
+		if def.matchSolar(o) {
+			festivals = append(festivals, Festival{Type: def.festivalType, Name: def.Name, SolarDay: o, Tags: def.Tags})
+		}
+	}
+	if term := computeSolarTerm(o); term != nil {
+		festivals = append(festivals, Festival{Type: FestivalTypeSolarTerm, Name: term.GetName(), SolarDay: o})
+	}
+	return append(festivals, computeSeasonFestivals(o)...)
+}
+
+// computeSolarTerm 逐个计算节气，获取当天节气
+func computeSolarTerm(o SolarDay) *SolarTerm {
+	for _, y := range []int{o.year, o.year + 1} {
+		for i := 0; i < 24; i++ {
+			term := NewSolarTermFromIndex(y, i)
+			if term.GetSolarDay().Equals(o) {
+				return &term
+			}
+		}
+	}
+	return nil
+}
+
+// computeSeasonFestivals 逐日计算当天开始的数九、三伏，作为时令节日
+func computeSeasonFestivals(o SolarDay) []Festival {
+	var festivals []Festival
+	if d := o.GetShuJiu(); d != nil && d.dayIndex == 0 {
+		festivals = append(festivals, Festival{Type: FestivalTypeSeason, Name: d.GetName(), SolarDay: o})
+	}
+	if d := o.GetDogDay(); d != nil && d.dayIndex == 0 {
+		festivals = append(festivals, Festival{Type: FestivalTypeSeason, Name: d.GetName(), SolarDay: o})
+	}
+	return festivals
+}
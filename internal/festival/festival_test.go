@@ -1,14 +1,18 @@
 package festival_test
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"workoff-timer/internal/festival"
 )
 
-// ExampleGetNearestFestival 获取最近节日示例
-func ExampleGetNearestFestival() {
+// ExampleSolarDay_GetNearestFestival 获取最近节日示例
+func ExampleSolarDay_GetNearestFestival() {
 	// 从今天开始查找30天内最近的节日
 	f := festival.Today().GetNearestFestival(30)
 	if f != nil {
@@ -34,3 +38,499 @@ func TestFestival(t *testing.T) {
 	}
 	fmt.Printf("找到节气: %s, 日期: %s\n", f2.Name, f2.SolarDay)
 }
+
+// TestGetFestivals 同一天多个节日测试
+func TestGetFestivals(t *testing.T) {
+	cases := []struct {
+		y, m, d int
+		names   []string
+	}{
+		{2020, 10, 1, []string{"中秋节", "国庆节"}},
+		{2024, 12, 21, []string{"冬至节", "冬至"}},
+		{2025, 4, 4, []string{"清明节", "清明"}},
+		{2025, 1, 1, []string{"元旦"}},
+	}
+	for _, c := range cases {
+		day, _ := festival.NewSolarDay(c.y, c.m, c.d)
+		festivals := day.GetFestivals()
+		if len(festivals) != len(c.names) {
+			t.Errorf("%s 期望 %v, 实际 %v", day, c.names, festivals)
+			continue
+		}
+		for i, f := range festivals {
+			if f.Name != c.names[i] {
+				t.Errorf("%s 第%d个节日期望 %s, 实际 %s", day, i+1, c.names[i], f.Name)
+			}
+		}
+	}
+
+	day, _ := festival.NewSolarDay(2020, 9, 25)
+	if festivals := day.GetNearestFestivals(10); len(festivals) != 2 {
+		t.Errorf("期望找到中秋节和国庆节, 实际 %v", festivals)
+	}
+}
+
+// TestLunarFormat 农历日期名称测试
+func TestLunarFormat(t *testing.T) {
+	d, _ := festival.NewLunarDay(2026, 12, 23)
+	if s := d.String(); s != "农历二〇二六年腊月廿三" {
+		t.Errorf("期望农历二〇二六年腊月廿三, 实际 %s", s)
+	}
+	leap, _ := festival.NewLunarDay(2025, -6, 1)
+	if s := leap.Format("%M%D"); s != "闰六月初一" {
+		t.Errorf("期望闰六月初一, 实际 %s", s)
+	}
+	if s := leap.Format("%G年 %%"); s != "乙巳年 %" {
+		t.Errorf("期望乙巳年 %%, 实际 %s", s)
+	}
+	// 农历二〇二五年十月从2025年11月20日到12月19日，共30天
+	m, _ := festival.NewLunarMonth(2025, 10)
+	if s := m.Format("%M%S"); s != "十月大" {
+		t.Errorf("期望十月大, 实际 %s", s)
+	}
+	m11, _ := festival.NewLunarMonth(2025, 11)
+	if m11.GetName() != "冬月" || m11.String() != "农历二〇二五年冬月" {
+		t.Errorf("期望冬月, 实际 %s", m11)
+	}
+	d30, _ := festival.NewLunarDay(2025, 1, 30)
+	if d30.GetName() != "三十" {
+		t.Errorf("期望三十, 实际 %s", d30.GetName())
+	}
+}
+
+// TestSubtract 公历日相减只按日期计算
+func TestSubtract(t *testing.T) {
+	a, _ := festival.NewSolarDay(2025, 1, 29)
+	b, _ := festival.NewSolarDay(2025, 1, 30)
+	if n := b.Subtract(a); n != 1 {
+		t.Errorf("期望相差1天, 实际 %d", n)
+	}
+	if n := a.Subtract(b); n != -1 {
+		t.Errorf("期望相差-1天, 实际 %d", n)
+	}
+	c, _ := festival.NewSolarDay(2024, 12, 31)
+	if n := b.Subtract(c); n != 30 {
+		t.Errorf("期望相差30天, 实际 %d", n)
+	}
+	// 朔日儒略日为正午，朔日次日不应算作初三
+	if s := b.GetLunarDay().Format("%M%D"); s != "正月初二" {
+		t.Errorf("2025年1月30日期望正月初二, 实际 %s", s)
+	}
+}
+
+// TestSolarTermTime 节气交节时刻测试
+func TestSolarTermTime(t *testing.T) {
+	term := festival.NewSolarTermFromIndex(2025, 3)
+	if s := term.GetSolarTime().String(); s != "2025年2月3日 22:10:28" {
+		t.Errorf("期望立春交节时刻2025年2月3日 22:10:28, 实际 %s", s)
+	}
+	utc := term.GetTime(time.UTC)
+	if utc.Day() != 3 || utc.Hour() != 14 || utc.Minute() != 10 {
+		t.Errorf("期望UTC 2025-02-03 14:10, 实际 %s", utc)
+	}
+	if !term.GetSolarDay().Equals(term.GetSolarTime().GetSolarDay()) {
+		t.Errorf("交节日期应与节气日期一致")
+	}
+
+	day, _ := festival.NewSolarDay(2026, 2, 1)
+	if next := day.GetNearestSolarTerm(); next.GetName() != "立春" || next.Next(1).GetName() != "雨水" {
+		t.Errorf("期望最近节气为立春, 实际 %s", next.GetName())
+	}
+}
+
+// TestLunarRecurrence 农历周年测试
+func TestLunarRecurrence(t *testing.T) {
+	r, _ := festival.NewLunarRecurrence(8, 15)
+	from, _ := festival.NewSolarDay(2025, 1, 1)
+	if d, ok := r.Next(from); !ok || d.String() != "2025年10月6日" {
+		t.Errorf("期望2025年中秋为2025年10月6日, 实际 %s", d)
+	}
+	from, _ = festival.NewSolarDay(2025, 10, 7)
+	if d, ok := r.Next(from); !ok || d.String() != "2026年9月25日" {
+		t.Errorf("期望2026年中秋为2026年9月25日, 实际 %s", d)
+	}
+
+	// 闰六月生日：默认按平月，严格模式只在闰六月出现
+	leap, _ := festival.NewLunarRecurrence(-6, 1)
+	from, _ = festival.NewSolarDay(2026, 1, 1)
+	if d, ok := leap.Next(from); !ok || d.GetLunarDay().GetMonth() != 6 {
+		t.Errorf("期望按六月初一计算, 实际 %s", d)
+	}
+	if d, ok := leap.WithLeapMonthPolicy(festival.LeapMonthStrict).Next(from); !ok || d.GetLunarDay().GetMonth() != -6 {
+		t.Errorf("期望下一个闰六月初一, 实际 %s", d)
+	}
+
+	// 小月没有三十
+	year := 2025
+	m, _ := festival.NewLunarMonth(year, 12)
+	for m.GetDayCount() == 30 {
+		year++
+		m, _ = festival.NewLunarMonth(year, 12)
+	}
+	r30, _ := festival.NewLunarRecurrence(12, 30)
+	if d, ok := r30.GetSolarDayInYear(year); !ok || d.GetLunarDay().GetDay() != 29 {
+		t.Errorf("期望提前到腊月廿九, 实际 %s", d)
+	}
+	if d, ok := r30.WithMissingDayPolicy(festival.MissingDayNextDay).GetSolarDayInYear(year); !ok || d.GetLunarDay().GetDay() != 1 {
+		t.Errorf("期望顺延到正月初一, 实际 %s", d)
+	}
+	if _, ok := r30.WithMissingDayPolicy(festival.MissingDaySkip).GetSolarDayInYear(year); ok {
+		t.Errorf("期望%d年不出现", year)
+	}
+}
+
+// TestSeason 数九、三伏测试
+func TestSeason(t *testing.T) {
+	day, _ := festival.NewSolarDay(2026, 1, 11)
+	if d := day.GetShuJiu(); d == nil || d.String() != "三九 第4天" {
+		t.Errorf("期望三九 第4天, 实际 %v", d)
+	}
+	day, _ = festival.NewSolarDay(2025, 12, 20)
+	if d := day.GetShuJiu(); d != nil {
+		t.Errorf("冬至前不应在数九期间, 实际 %v", d)
+	}
+
+	// 2024年中伏20天，2025年中伏10天
+	cases := []struct {
+		y, m, d int
+		name    string
+		count   int
+	}{
+		{2024, 7, 15, "初伏 第1天", 10},
+		{2024, 7, 31, "中伏 第7天", 20},
+		{2024, 8, 23, "末伏 第10天", 10},
+		{2025, 8, 5, "中伏 第7天", 10},
+	}
+	for _, c := range cases {
+		d, _ := festival.NewSolarDay(c.y, c.m, c.d)
+		dog := d.GetDogDay()
+		if dog == nil || dog.String() != c.name || dog.GetDayCount() != c.count {
+			t.Errorf("%s 期望%s(共%d天), 实际 %v", d, c.name, c.count, dog)
+		}
+	}
+	day, _ = festival.NewSolarDay(2024, 8, 24)
+	if d := day.GetDogDay(); d != nil {
+		t.Errorf("期望已出伏, 实际 %v", d)
+	}
+
+	// 时令默认不作为节日，需要WithSeasons
+	day, _ = festival.NewSolarDay(2025, 7, 19)
+	if f := day.GetNearestFestival(3); f != nil && f.Type == festival.FestivalTypeSeason {
+		t.Errorf("默认不应包含时令")
+	}
+	if f := day.GetNearestFestival(3, festival.WithSeasons()); f == nil || f.Name != "初伏" {
+		t.Errorf("期望找到初伏, 实际 %v", f)
+	}
+
+	// 数据集中自定义的时令不受WithSeasons控制
+	custom, err := festival.LoadDataset("custom.json", strings.NewReader(`{
+  "version": 1,
+  "festivals": [
+    {"name": "开渔节", "calendar": "solar", "rule": {"month": 9, "day": 16}, "type": "season"}
+  ]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	festival.SetDataset(festival.DefaultDataset().Merge(custom))
+	defer festival.SetDataset(nil)
+	day, _ = festival.NewSolarDay(2025, 9, 16)
+	if fs := day.GetFestivals(); len(fs) != 1 || fs[0].Name != "开渔节" || fs[0].Type != festival.FestivalTypeSeason {
+		t.Errorf("期望开渔节, 实际 %v", fs)
+	}
+}
+
+// TestFestivalsRange 节日遍历与过滤测试
+func TestFestivalsRange(t *testing.T) {
+	start, _ := festival.NewSolarDay(2025, 1, 1)
+	end, _ := festival.NewSolarDay(2025, 12, 31)
+
+	count := 0
+	for range festival.Festivals(start, end, festival.WithTypes(festival.FestivalTypeSolarTerm)) {
+		count++
+	}
+	if count != 24 {
+		t.Errorf("2025年期望24个节气, 实际 %d", count)
+	}
+
+	var names []string
+	for f := range festival.Festivals(end, start, festival.WithTypes(festival.FestivalTypeSolar)) {
+		names = append(names, f.Name)
+		if len(names) == 2 {
+			break
+		}
+	}
+	if len(names) != 2 || names[0] != "感恩节" || names[1] != "国庆节" {
+		t.Errorf("倒序期望感恩节、国庆节, 实际 %v", names)
+	}
+
+	for f := range festival.Festivals(start, end, festival.WithNames("春节")) {
+		if f.SolarDay.String() != "2025年1月29日" {
+			t.Errorf("期望春节为2025年1月29日, 实际 %s", f.SolarDay)
+		}
+	}
+
+	weekend := festival.WithFilter(func(f festival.Festival) bool { return f.SolarDay.GetWeek().IsWeekend() })
+	for f := range festival.Festivals(start, end, weekend) {
+		if !f.SolarDay.GetWeek().IsWeekend() {
+			t.Errorf("%s 不是周末", f)
+		}
+	}
+
+	day, _ := festival.NewSolarDay(2025, 10, 20)
+	if f := day.GetPreviousFestival(30); f == nil || f.Name != "寒露" {
+		t.Errorf("期望上一个节日为寒露, 实际 %v", f)
+	}
+	f := day.GetPreviousFestival(30, festival.WithTypes(festival.FestivalTypeLunar))
+	if f == nil || f.Name != "中秋节" || f.DaysSince(day) != 14 {
+		t.Errorf("期望14天前是中秋节, 实际 %v", f)
+	}
+}
+
+// TestSolarFestivalRule 按星期规则的公历节日测试
+func TestSolarFestivalRule(t *testing.T) {
+	cases := []struct {
+		y, m, d int
+		name    string
+	}{
+		{2025, 5, 11, "母亲节"},
+		{2025, 6, 15, "父亲节"},
+		{2025, 11, 27, "感恩节"},
+		{2026, 5, 10, "母亲节"},
+	}
+	for _, c := range cases {
+		f, _ := festival.GetSolarFestivalByYmd(c.y, c.m, c.d)
+		if f == nil || f.GetName() != c.name {
+			t.Errorf("%d-%d-%d 期望%s, 实际 %v", c.y, c.m, c.d, c.name, f)
+		}
+	}
+	if f, _ := festival.GetSolarFestivalByYmd(2025, 5, 4); f == nil || f.GetName() != "五四青年节" {
+		t.Errorf("期望五四青年节, 实际 %v", f)
+	}
+	if f, _ := festival.GetSolarFestivalByYmd(1913, 5, 11); f != nil {
+		t.Errorf("1914年前不应有母亲节, 实际 %v", f)
+	}
+
+	// 最后一个星期几及结束年份
+	custom, err := festival.LoadDataset("custom.json", strings.NewReader(`{"version": 1, "festivals": [
+		{"name": "测试节", "calendar": "solar", "rule": {"month": 5, "week": -1, "weekday": 1}, "startYear": 1971, "endYear": 2030}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	festival.SetDataset(custom)
+	defer festival.SetDataset(nil)
+	if f, _ := festival.GetSolarFestivalByYmd(2025, 5, 26); f == nil || f.GetName() != "测试节" {
+		t.Errorf("期望5月最后一个星期一为测试节, 实际 %v", f)
+	}
+	if f, _ := festival.GetSolarFestivalByYmd(2025, 5, 19); f != nil {
+		t.Errorf("5月19日不是最后一个星期一, 实际 %v", f)
+	}
+	if f, _ := festival.GetSolarFestivalByYmd(2031, 5, 26); f != nil {
+		t.Errorf("2030年后不应有测试节, 实际 %v", f)
+	}
+}
+
+// TestLoadDataset 节日数据集测试
+func TestLoadDataset(t *testing.T) {
+	custom, err := festival.LoadDataset("custom.json", strings.NewReader(`{
+  "version": 1,
+  "festivals": [
+    {"name": "公司周年庆", "calendar": "solar", "rule": {"month": 4, "day": 18}, "startYear": 2015, "tags": ["公司"]},
+    {"name": "公司成立日", "calendar": "solar", "rule": {"month": 10, "day": 1}},
+    {"name": "月末", "calendar": "lunar", "rule": {"month": 2, "day": -1}}
+  ]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	festival.SetDataset(festival.DefaultDataset().Merge(custom))
+	defer festival.SetDataset(nil)
+
+	day, _ := festival.NewSolarDay(2026, 4, 18)
+	fs := day.GetFestivals()
+	if len(fs) != 1 || fs[0].Name != "公司周年庆" || fs[0].Type != festival.FestivalTypeSolar || fs[0].Tags[0] != "公司" {
+		t.Errorf("期望公司周年庆, 实际 %v", fs)
+	}
+	// 农历2026年二月小，二月廿九为月末
+	if f, _ := festival.GetLunarFestivalByYmd(2026, 2, 29); f == nil || f.GetName() != "月末" {
+		t.Errorf("期望月末, 实际 %v", f)
+	}
+	// 内置节日仍然有效
+	if f, _ := festival.GetSolarFestivalByYmd(2026, 10, 1); f == nil || f.GetName() != "国庆节" {
+		t.Errorf("期望国庆节, 实际 %v", f)
+	}
+	// 与内置节日同一天时都保留，内置节日在前
+	day, _ = festival.NewSolarDay(2026, 10, 1)
+	var names []string
+	for _, f := range day.GetFestivals() {
+		if f.Type == festival.FestivalTypeSolar {
+			names = append(names, f.Name)
+		}
+	}
+	if strings.Join(names, ",") != "国庆节,公司成立日" {
+		t.Errorf("期望国庆节,公司成立日, 实际 %v", names)
+	}
+	// 逐日算法同样保留同一天的全部节日
+	if a, b := day.GetFestivals(festival.WithSeasons()), festival.ComputeFestivals(day); !slices.EqualFunc(a, b, func(x, y festival.Festival) bool { return x.String() == y.String() }) {
+		t.Errorf("%s 节日不一致: %v %v", day, a, b)
+	}
+
+	cases := []struct {
+		data      string
+		line, col int
+		message   string
+	}{
+		{"{\"version\": 2, \"festivals\": []}", 1, 13, "不支持的版本"},
+		{"{\"version\": 1,\n\"festivals\": [\n  {\"name\": \"a\", \"calendar\": \"solar\", \"rule\": {\"month\": 13, \"day\": 1}}\n]}", 3, 47, "非法月份"},
+		{"{\"version\": 1,\n\"festivals\": [\n  {\"name\": \"a\", \"calendar\": \"lunar\", \"rule\": {\"term\": \"春分秋\"}}\n]}", 3, 47, "非法节气"},
+		{"{\"version\": 1,\n\"festivals\": [\n  {\"name\": \"a\", \"calendar\": \"solar\", \"date\": \"0101\"}\n]}", 3, 3, "unknown field"},
+		{"{\"version\": 1,\n\"festivals\": [\n  {\"name\": \"a\",}\n]}", 3, 17, "invalid character"},
+	}
+	for _, c := range cases {
+		_, err := festival.LoadDataset("bad.json", strings.NewReader(c.data))
+		var de *festival.DatasetError
+		if !errors.As(err, &de) || de.Line != c.line || de.Column != c.col || !strings.Contains(err.Error(), c.message) {
+			t.Errorf("%q 期望 %d:%d %s, 实际 %v", c.data, c.line, c.col, c.message, err)
+		}
+	}
+}
+
+// TestPersonalEvents 个人纪念日测试
+func TestPersonalEvents(t *testing.T) {
+	events, err := festival.LoadPersonalEvents("events.json", strings.NewReader(`{
+  "version": 1,
+  "events": [
+    {"name": "妈妈", "kind": "birthday", "calendar": "lunar", "year": 1966, "month": 8, "day": 15},
+    {"name": "爸爸", "kind": "birthday", "calendar": "lunar", "year": 1964, "month": 8, "day": 15, "age": "nominal"},
+    {"name": "结婚", "kind": "anniversary", "calendar": "solar", "year": 2021, "month": 5, "day": 20},
+    {"name": "小明", "kind": "birthday", "calendar": "solar", "year": 2000, "month": 2, "day": 29}
+  ]
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2026年中秋节为公历9月25日，生日排在中秋节之前
+	day, _ := festival.NewSolarDay(2026, 9, 25)
+	fs := day.GetFestivals(festival.WithPersonalEvents(events...))
+	if len(fs) != 3 || fs[0].Name != "妈妈 60 岁生日" || fs[1].Name != "爸爸 63 岁生日" || fs[2].Name != "中秋节" {
+		t.Errorf("期望妈妈 60 岁生日、爸爸 63 岁生日、中秋节, 实际 %v", fs)
+	}
+	if fs[0].Type != festival.FestivalTypePersonal {
+		t.Errorf("期望个人纪念日类型, 实际 %v", fs[0].Type)
+	}
+	// 不带选项时不包含个人纪念日
+	if fs := day.GetFestivals(); len(fs) != 1 {
+		t.Errorf("期望只有中秋节, 实际 %v", fs)
+	}
+
+	day, _ = festival.NewSolarDay(2026, 5, 1)
+	f := day.GetNearestFestival(30, festival.WithPersonalEvents(events...), festival.WithTypes(festival.FestivalTypePersonal))
+	if f == nil || f.Name != "结婚 5 周年" || f.SolarDay.String() != "2026年5月20日" {
+		t.Errorf("期望2026年5月20日结婚 5 周年, 实际 %v", f)
+	}
+
+	// 2月29日出生，平年按2月28日过
+	day, _ = festival.NewSolarDay(2025, 2, 28)
+	if fs := day.GetFestivals(festival.WithPersonalEvents(events...)); len(fs) != 1 || fs[0].Name != "小明 25 岁生日" {
+		t.Errorf("期望小明 25 岁生日, 实际 %v", fs)
+	}
+
+	day, _ = festival.NewSolarDay(2026, 10, 18)
+	if d, n, ok := events[0].Next(day); !ok || d.String() != "2027年9月15日" || n != 61 {
+		t.Errorf("期望2027年9月15日 61 岁, 实际 %v %d %v", d, n, ok)
+	}
+
+	_, err = festival.LoadPersonalEvents("events.json", strings.NewReader(`{"version": 1, "events": [
+  {"name": "妈妈", "kind": "birthday", "calendar": "lunar", "year": 1966, "month": 8, "day": 31}
+]}`))
+	var de *festival.DatasetError
+	if !errors.As(err, &de) || de.Line != 2 || de.Column != 85 {
+		t.Errorf("期望第2行第85列的错误, 实际 %v", err)
+	}
+}
+
+// TestSupportedRange 支持范围与带错误返回的查询
+func TestSupportedRange(t *testing.T) {
+	if _, err := festival.NewSolarDay(festival.MaxYear+1, 1, 1); !errors.Is(err, festival.ErrOutOfRange) {
+		t.Errorf("期望ErrOutOfRange, 实际 %v", err)
+	}
+	if _, err := festival.NewLunarDay(2025, -5, 1); !errors.Is(err, festival.ErrInvalidLeapMonth) {
+		t.Errorf("期望ErrInvalidLeapMonth, 实际 %v", err)
+	}
+	if _, err := festival.NewLunarDay(2025, -6, 1); err != nil {
+		t.Errorf("2025年有闰六月: %v", err)
+	}
+
+	// 范围首尾两天可以正常计算，超出一天返回错误
+	first, _ := festival.NewSolarDay(festival.MinYear, 1, 1)
+	last, _ := festival.NewSolarDay(festival.MaxYear, 12, 31)
+	for _, d := range []festival.SolarDay{first, last} {
+		l, err := festival.LunarDayOf(d)
+		if err != nil || !l.GetSolarDay().Equals(d) {
+			t.Errorf("%s 期望农历日可以换算回来, 实际 %v %v", d, l.GetSolarDay(), err)
+		}
+	}
+	for _, d := range []festival.SolarDay{first.Next(-1), last.Next(1)} {
+		if _, err := festival.LunarDayOf(d); !errors.Is(err, festival.ErrOutOfRange) {
+			t.Errorf("%s 期望ErrOutOfRange, 实际 %v", d, err)
+		}
+		if _, err := festival.FestivalsOf(d); !errors.Is(err, festival.ErrOutOfRange) {
+			t.Errorf("%s 期望ErrOutOfRange, 实际 %v", d, err)
+		}
+		if f := d.GetFestivals(); f != nil {
+			t.Errorf("%s 超出范围不应有节日, 实际 %v", d, f)
+		}
+	}
+	l := last.GetLunarDay()
+	if _, err := festival.NewLunarDay(l.GetYear(), l.GetMonth(), l.GetDay()); err != nil {
+		t.Errorf("%s 期望有效, 实际 %v", l, err)
+	}
+	if _, err := festival.NewLunarDay(l.GetYear(), l.GetMonth(), l.GetDay()+1); !errors.Is(err, festival.ErrOutOfRange) {
+		t.Errorf("%s 的下一天期望ErrOutOfRange, 实际 %v", l, err)
+	}
+	// 推移超出范围时返回零值
+	if d := l.Next(1); d != (festival.LunarDay{}) {
+		t.Errorf("%s 的下一天期望零值, 实际 %v", l, d)
+	}
+	m, _ := festival.NewLunarMonth(festival.MaxYear, 12)
+	if n := m.Next(1); n != (festival.LunarMonth{}) {
+		t.Errorf("%s 的下一月期望零值, 实际 %v", m, n)
+	}
+	if y := m.Next(0); y != m {
+		t.Errorf("期望%s, 实际 %v", m, y)
+	}
+}
+
+// TestDaysUntil 倒计时只比较日期，精确时长到目标日期零点
+func TestDaysUntil(t *testing.T) {
+	loc := festival.CalendarLocation()
+	target, _ := festival.NewSolarDay(2026, 10, 1)
+	cases := []struct {
+		at       time.Time
+		days     int
+		duration time.Duration
+	}{
+		{time.Date(2026, 9, 30, 0, 0, 0, 0, loc), 1, 24 * time.Hour},
+		{time.Date(2026, 9, 30, 13, 0, 0, 0, loc), 1, 11 * time.Hour},
+		{time.Date(2026, 9, 30, 23, 59, 59, 0, loc), 1, time.Second},
+		{time.Date(2026, 10, 1, 0, 0, 0, 0, loc), 0, 0},
+		{time.Date(2026, 10, 2, 6, 0, 0, 0, loc), -1, -30 * time.Hour},
+	}
+	for _, c := range cases {
+		now := festival.NewSolarDayFromTime(c.at)
+		if d := now.DaysUntil(target); d != c.days {
+			t.Errorf("%s 期望 %d 天, 实际 %d", c.at, c.days, d)
+		}
+		if d := now.DurationUntil(target); d != c.duration {
+			t.Errorf("%s 期望 %s, 实际 %s", c.at, c.duration, d)
+		}
+	}
+
+	f := festival.Festival{Name: "国庆节", SolarDay: target}
+	now := festival.NewSolarDayFromTime(time.Date(2026, 9, 21, 18, 0, 0, 0, loc))
+	if f.DaysUntil(now) != 10 || f.DaysSince(now) != -10 || f.DurationUntil(now) != 9*24*time.Hour+6*time.Hour {
+		t.Errorf("期望10天, 实际 %d %s", f.DaysUntil(now), f.DurationUntil(now))
+	}
+}
@@ -0,0 +1,342 @@
+package festival
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ============ 法定节假日 ============
+
+// TagStatutory 法定节日的标签，这些节日按放假安排放假
+const TagStatutory = "法定"
+
+// Holiday 法定节假日安排（一段连续放假日期及其调休上班日）
+type Holiday struct {
+	name     string
+	start    SolarDay
+	end      SolarDay
+	workdays []SolarDay
+	// statutory 放假期间的法定假日当天，其余为调休形成的休息日
+	statutory []SolarDay
+}
+
+// NewHoliday 创建法定节假日安排
+// start、end: 放假起止日期（含）; workdays: 调休上班日
+func NewHoliday(name string, start SolarDay, end SolarDay, workdays ...SolarDay) (Holiday, error) {
+	if end.Subtract(start) < 0 {
+		return Holiday{}, fmt.Errorf("非法放假日期: %s 至 %s", start, end)
+	}
+	return Holiday{name: name, start: start, end: end, workdays: workdays}, nil
+}
+
+// GetName 获取名称
+func (o Holiday) GetName() string { return o.name }
+
+// GetStartDay 获取放假首日
+func (o Holiday) GetStartDay() SolarDay { return o.start }
+
+// GetEndDay 获取放假末日
+func (o Holiday) GetEndDay() SolarDay { return o.end }
+
+// GetDayCount 获取放假天数
+func (o Holiday) GetDayCount() int { return o.end.Subtract(o.start) + 1 }
+
+// GetWorkdays 获取调休上班日
+func (o Holiday) GetWorkdays() []SolarDay { return o.workdays }
+
+// WithStatutoryDays 指定放假期间的法定假日当天，不在放假期间时返回错误
+func (o Holiday) WithStatutoryDays(days ...SolarDay) (Holiday, error) {
+	for _, d := range days {
+		if !o.Contains(d) {
+			return Holiday{}, fmt.Errorf("法定假日不在放假期间: %s %s", o, d)
+		}
+	}
+	o.statutory = days
+	return o, nil
+}
+
+// GetStatutoryDays 获取法定假日当天
+func (o Holiday) GetStatutoryDays() []SolarDay { return o.statutory }
+
+// Contains 指定日期是否在放假期间
+func (o Holiday) Contains(d SolarDay) bool {
+	return d.Subtract(o.start) >= 0 && o.end.Subtract(d) >= 0
+}
+
+// IsWorkday 指定日期是否为本次安排的调休上班日
+func (o Holiday) IsWorkday(d SolarDay) bool {
+	for _, w := range o.workdays {
+		if w.Equals(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsStatutory 指定日期是否为本次安排中的法定假日当天
+func (o Holiday) IsStatutory(d SolarDay) bool {
+	for _, s := range o.statutory {
+		if s.Equals(d) {
+			return true
+		}
+	}
+	return false
+}
+
+// String 字符串表示
+func (o Holiday) String() string {
+	return fmt.Sprintf("%s %s至%s", o.name, o.start, o.end)
+}
+
+// ============ 节假日日历 ============
+
+// HolidayCalendar 节假日日历（国务院办公厅每年发布的放假安排）
+type HolidayCalendar struct {
+	mu       sync.RWMutex
+	holidays map[int][]Holiday
+}
+
+// NewHolidayCalendar 创建节假日日历，预置 HolidayData 中的放假安排
+func NewHolidayCalendar() *HolidayCalendar {
+	c := &HolidayCalendar{holidays: map[int][]Holiday{}}
+	for _, h := range HolidayData {
+		c.Add(h)
+	}
+	return c
+}
+
+// Add 添加放假安排，按放假首日所在年份归档
+func (c *HolidayCalendar) Add(h Holiday) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	year := h.start.GetYear()
+	list := append(c.holidays[year], h)
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].start.Subtract(list[j].start) < 0
+	})
+	c.holidays[year] = list
+}
+
+// GetHolidays 获取某年的放假安排
+func (c *HolidayCalendar) GetHolidays(year int) []Holiday {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]Holiday(nil), c.holidays[year]...)
+}
+
+// HasYear 是否收录了某年的放假安排
+func (c *HolidayCalendar) HasYear(year int) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.holidays[year]) > 0
+}
+
+// find 查找与指定日期相关（放假或调休）的安排，跨年安排归档在前一年
+func (c *HolidayCalendar) find(d SolarDay, match func(Holiday, SolarDay) bool) *Holiday {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, year := range []int{d.GetYear(), d.GetYear() - 1, d.GetYear() + 1} {
+		for _, h := range c.holidays[year] {
+			if match(h, d) {
+				return &h
+			}
+		}
+	}
+	return nil
+}
+
+// GetHoliday 获取指定日期所在的放假安排，不在放假期间返回nil
+func (c *HolidayCalendar) GetHoliday(d SolarDay) *Holiday {
+	return c.find(d, Holiday.Contains)
+}
+
+// IsHoliday 是否为法定节假日放假日
+func (c *HolidayCalendar) IsHoliday(d SolarDay) bool {
+	return c.GetHoliday(d) != nil
+}
+
+// IsMakeupWorkday 是否为调休上班日
+func (c *HolidayCalendar) IsMakeupWorkday(d SolarDay) bool {
+	return c.find(d, Holiday.IsWorkday) != nil
+}
+
+// IsWorkday 是否为工作日：调休上班日为工作日，放假日为休息日，其余按周一至周五计
+func (c *HolidayCalendar) IsWorkday(d SolarDay) bool {
+	if c.IsMakeupWorkday(d) {
+		return true
+	}
+	if c.IsHoliday(d) {
+		return false
+	}
+	return !d.GetWeek().IsWeekend()
+}
+
+// NextRestDay 获取从指定日期起（含当天）的第一个休息日
+func (c *HolidayCalendar) NextRestDay(d SolarDay) SolarDay {
+	for c.IsWorkday(d) {
+		d = d.Next(1)
+	}
+	return d
+}
+
+// NextWorkday 获取从指定日期起（含当天）的第一个工作日
+func (c *HolidayCalendar) NextWorkday(d SolarDay) SolarDay {
+	for !c.IsWorkday(d) {
+		d = d.Next(1)
+	}
+	return d
+}
+
+// IsStatutoryHoliday 是否为法定假日当天（加班按300%支付工资），不含调休形成的连休和周末
+// 按放假安排中指定的法定假日判断，没有收录的年份都返回false
+func (c *HolidayCalendar) IsStatutoryHoliday(d SolarDay) bool {
+	return c.find(d, Holiday.IsStatutory) != nil
+}
+
+// GetHolidayStatus 获取指定日期的放假状态，不在放假期间返回nil
+func (c *HolidayCalendar) GetHolidayStatus(d SolarDay) *HolidayStatus {
+	h := c.GetHoliday(d)
+	if h == nil {
+		return nil
+	}
+	return &HolidayStatus{holiday: *h, day: d, backToWork: c.NextWorkday(h.end.Next(1))}
+}
+
+// ============ 放假状态 ============
+
+// HolidayStatus 放假期间某一天的状态：第几天、共几天、何时上班
+type HolidayStatus struct {
+	holiday    Holiday
+	day        SolarDay
+	backToWork SolarDay
+}
+
+// GetHoliday 获取所在的放假安排
+func (o HolidayStatus) GetHoliday() Holiday { return o.holiday }
+
+// GetDayIndex 获取放假第几天，从1开始
+func (o HolidayStatus) GetDayIndex() int { return o.day.Subtract(o.holiday.start) + 1 }
+
+// GetDayCount 获取放假天数
+func (o HolidayStatus) GetDayCount() int { return o.holiday.GetDayCount() }
+
+// GetBackToWorkDay 获取收假后的第一个工作日（可能是调休上班日）
+func (o HolidayStatus) GetBackToWorkDay() SolarDay { return o.backToWork }
+
+// GetDaysUntilBackToWork 收假倒计时：距离上班还有几天
+func (o HolidayStatus) GetDaysUntilBackToWork() int { return o.day.DaysUntil(o.backToWork) }
+
+// String 字符串表示，如“国庆节 第3天/共8天 5天后上班”
+func (o HolidayStatus) String() string {
+	return fmt.Sprintf("%s 第%d天/共%d天 %d天后上班", o.holiday.name, o.GetDayIndex(), o.GetDayCount(), o.GetDaysUntilBackToWork())
+}
+
+// ============ 节日放假区间 ============
+
+// GetStartDay 获取节日首日，放假的法定节日为放假首日
+func (f Festival) GetStartDay() SolarDay {
+	if f.Holiday != nil {
+		return f.Holiday.start
+	}
+	return f.SolarDay
+}
+
+// GetEndDay 获取节日末日，放假的法定节日为放假末日
+func (f Festival) GetEndDay() SolarDay {
+	if f.Holiday != nil {
+		return f.Holiday.end
+	}
+	return f.SolarDay
+}
+
+// GetDayCount 获取节日持续天数，放假的法定节日为放假天数，其余为1
+func (f Festival) GetDayCount() int {
+	return f.GetEndDay().Subtract(f.GetStartDay()) + 1
+}
+
+// ============ 放假安排数据 ============
+
+// mustSolarDay 根据年月日创建公历日，数据错误时panic，仅用于内置数据
+func mustSolarDay(ymd [3]int) SolarDay {
+	d, err := NewSolarDay(ymd[0], ymd[1], ymd[2])
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// mustHoliday 根据年月日创建放假安排，数据错误时panic，仅用于内置数据
+func mustHoliday(name string, start [3]int, end [3]int, workdays ...[3]int) Holiday {
+	w := make([]SolarDay, 0, len(workdays))
+	for _, ymd := range workdays {
+		w = append(w, mustSolarDay(ymd))
+	}
+	h, err := NewHoliday(name, mustSolarDay(start), mustSolarDay(end), w...)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// mustStatutory 根据年月日指定法定假日当天，数据错误时panic，仅用于内置数据
+func (o Holiday) mustStatutory(days ...[3]int) Holiday {
+	d := make([]SolarDay, 0, len(days))
+	for _, ymd := range days {
+		d = append(d, mustSolarDay(ymd))
+	}
+	h, err := o.WithStatutoryDays(d...)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// HolidayData 内置放假安排（国务院办公厅关于部分节假日安排的通知）
+// 法定假日当天按《全国年节及纪念日放假办法》：元旦、春节（初一至初三）、清明、劳动节、端午、中秋、国庆（10月1日至3日），
+// 2025年起春节增加除夕、劳动节增加5月2日
+var HolidayData = []Holiday{
+	// 2024年
+	mustHoliday("元旦", [3]int{2023, 12, 30}, [3]int{2024, 1, 1}).
+		mustStatutory([3]int{2024, 1, 1}),
+	mustHoliday("春节", [3]int{2024, 2, 10}, [3]int{2024, 2, 17}, [3]int{2024, 2, 4}, [3]int{2024, 2, 18}).
+		mustStatutory([3]int{2024, 2, 10}, [3]int{2024, 2, 11}, [3]int{2024, 2, 12}),
+	mustHoliday("清明节", [3]int{2024, 4, 4}, [3]int{2024, 4, 6}, [3]int{2024, 4, 7}).
+		mustStatutory([3]int{2024, 4, 4}),
+	mustHoliday("劳动节", [3]int{2024, 5, 1}, [3]int{2024, 5, 5}, [3]int{2024, 4, 28}, [3]int{2024, 5, 11}).
+		mustStatutory([3]int{2024, 5, 1}),
+	mustHoliday("端午节", [3]int{2024, 6, 8}, [3]int{2024, 6, 10}).
+		mustStatutory([3]int{2024, 6, 10}),
+	mustHoliday("中秋节", [3]int{2024, 9, 15}, [3]int{2024, 9, 17}, [3]int{2024, 9, 14}).
+		mustStatutory([3]int{2024, 9, 17}),
+	mustHoliday("国庆节", [3]int{2024, 10, 1}, [3]int{2024, 10, 7}, [3]int{2024, 9, 29}, [3]int{2024, 10, 12}).
+		mustStatutory([3]int{2024, 10, 1}, [3]int{2024, 10, 2}, [3]int{2024, 10, 3}),
+	// 2025年
+	mustHoliday("元旦", [3]int{2025, 1, 1}, [3]int{2025, 1, 1}).
+		mustStatutory([3]int{2025, 1, 1}),
+	mustHoliday("春节", [3]int{2025, 1, 28}, [3]int{2025, 2, 4}, [3]int{2025, 1, 26}, [3]int{2025, 2, 8}).
+		mustStatutory([3]int{2025, 1, 28}, [3]int{2025, 1, 29}, [3]int{2025, 1, 30}, [3]int{2025, 1, 31}),
+	mustHoliday("清明节", [3]int{2025, 4, 4}, [3]int{2025, 4, 6}).
+		mustStatutory([3]int{2025, 4, 4}),
+	mustHoliday("劳动节", [3]int{2025, 5, 1}, [3]int{2025, 5, 5}, [3]int{2025, 4, 27}).
+		mustStatutory([3]int{2025, 5, 1}, [3]int{2025, 5, 2}),
+	mustHoliday("端午节", [3]int{2025, 5, 31}, [3]int{2025, 6, 2}).
+		mustStatutory([3]int{2025, 5, 31}),
+	mustHoliday("国庆节、中秋节", [3]int{2025, 10, 1}, [3]int{2025, 10, 8}, [3]int{2025, 9, 28}, [3]int{2025, 10, 11}).
+		mustStatutory([3]int{2025, 10, 1}, [3]int{2025, 10, 2}, [3]int{2025, 10, 3}, [3]int{2025, 10, 6}),
+	// 2026年
+	mustHoliday("元旦", [3]int{2026, 1, 1}, [3]int{2026, 1, 3}, [3]int{2026, 1, 4}).
+		mustStatutory([3]int{2026, 1, 1}),
+	mustHoliday("春节", [3]int{2026, 2, 15}, [3]int{2026, 2, 23}, [3]int{2026, 2, 14}, [3]int{2026, 2, 28}).
+		mustStatutory([3]int{2026, 2, 16}, [3]int{2026, 2, 17}, [3]int{2026, 2, 18}, [3]int{2026, 2, 19}),
+	mustHoliday("清明节", [3]int{2026, 4, 4}, [3]int{2026, 4, 6}).
+		mustStatutory([3]int{2026, 4, 5}),
+	mustHoliday("劳动节", [3]int{2026, 5, 1}, [3]int{2026, 5, 5}, [3]int{2026, 5, 9}).
+		mustStatutory([3]int{2026, 5, 1}, [3]int{2026, 5, 2}),
+	mustHoliday("端午节", [3]int{2026, 6, 19}, [3]int{2026, 6, 21}).
+		mustStatutory([3]int{2026, 6, 19}),
+	mustHoliday("中秋节", [3]int{2026, 9, 25}, [3]int{2026, 9, 27}).
+		mustStatutory([3]int{2026, 9, 25}),
+	mustHoliday("国庆节", [3]int{2026, 10, 1}, [3]int{2026, 10, 7}, [3]int{2026, 9, 20}, [3]int{2026, 10, 10}).
+		mustStatutory([3]int{2026, 10, 1}, [3]int{2026, 10, 2}, [3]int{2026, 10, 3}),
+}
@@ -0,0 +1,132 @@
+package festival_test
+
+import (
+	"testing"
+
+	"workoff-timer/internal/festival"
+)
+
+// TestHolidayCalendar 法定节假日与调休测试
+func TestHolidayCalendar(t *testing.T) {
+	c := festival.NewHolidayCalendar()
+	cases := []struct {
+		y, m, d int
+		workday bool
+		holiday bool
+	}{
+		{2025, 10, 1, false, true},   // 国庆节
+		{2025, 9, 28, true, false},   // 周日调休上班
+		{2025, 10, 11, true, false},  // 周六调休上班
+		{2025, 10, 10, true, false},  // 普通周五
+		{2025, 10, 12, false, false}, // 普通周日
+		{2024, 1, 1, false, true},    // 跨年元旦
+		{2026, 2, 14, true, false},   // 春节前周六调休上班
+	}
+	for _, tc := range cases {
+		day, _ := festival.NewSolarDay(tc.y, tc.m, tc.d)
+		if got := c.IsWorkday(day); got != tc.workday {
+			t.Errorf("%s IsWorkday = %v, 期望 %v", day, got, tc.workday)
+		}
+		if got := c.IsHoliday(day); got != tc.holiday {
+			t.Errorf("%s IsHoliday = %v, 期望 %v", day, got, tc.holiday)
+		}
+	}
+
+	// 国庆中秋连休（10月1日至8日）后第一个工作日是10月9日周四
+	day, _ := festival.NewSolarDay(2025, 10, 3)
+	if next := c.NextWorkday(day); next.String() != "2025年10月9日" {
+		t.Errorf("期望下一个工作日为2025年10月9日, 实际 %s", next)
+	}
+}
+
+// TestHolidayStatus 放假状态与节日放假区间测试
+func TestHolidayStatus(t *testing.T) {
+	c := festival.NewHolidayCalendar()
+	day, _ := festival.NewSolarDay(2025, 10, 3)
+	s := c.GetHolidayStatus(day)
+	if s == nil || s.GetDayIndex() != 3 || s.GetDayCount() != 8 || s.GetDaysUntilBackToWork() != 6 {
+		t.Fatalf("期望国庆节、中秋节 第3天/共8天 6天后上班, 实际 %v", s)
+	}
+	if s.String() != "国庆节、中秋节 第3天/共8天 6天后上班" || s.GetBackToWorkDay().String() != "2025年10月9日" {
+		t.Errorf("实际 %s, 上班 %s", s, s.GetBackToWorkDay())
+	}
+	// 收假后第一个工作日是调休上班的周日
+	day, _ = festival.NewSolarDay(2026, 1, 2)
+	if s := c.GetHolidayStatus(day); s == nil || s.GetBackToWorkDay().String() != "2026年1月4日" {
+		t.Errorf("期望1月4日调休上班, 实际 %v", s)
+	}
+	day, _ = festival.NewSolarDay(2025, 10, 9)
+	if s := c.GetHolidayStatus(day); s != nil {
+		t.Errorf("不在放假期间, 实际 %v", s)
+	}
+
+	// 法定节日附上放假区间，其他节日不附
+	day, _ = festival.NewSolarDay(2025, 10, 6)
+	festivals := day.GetFestivals(festival.WithHolidayCalendar(c))
+	if len(festivals) == 0 || festivals[0].Name != "中秋节" || festivals[0].GetDayCount() != 8 || festivals[0].GetStartDay().String() != "2025年10月1日" {
+		t.Errorf("期望中秋节放假8天, 实际 %v", festivals)
+	}
+	day, _ = festival.NewSolarDay(2026, 2, 16)
+	for _, f := range day.GetFestivals(festival.WithHolidayCalendar(c)) {
+		if f.Name == "除夕" && (f.Holiday != nil || f.GetDayCount() != 1) {
+			t.Errorf("除夕不是法定节日, 实际 %v", f.Holiday)
+		}
+	}
+	if f := day.GetNearestFestival(10); f.Holiday != nil {
+		t.Errorf("未指定节假日日历时不应有放假区间")
+	}
+}
+
+// TestStatutoryHoliday 法定假日（加班三倍工资）测试
+func TestStatutoryHoliday(t *testing.T) {
+	c := festival.NewHolidayCalendar()
+	// 每年的法定假日天数：2025年起13天，没有收录放假安排的年份为0
+	for year, want := range map[int]int{2023: 0, 2024: 11, 2025: 13, 2026: 13, 2027: 0} {
+		count := 0
+		first, _ := festival.NewSolarDay(year, 1, 1)
+		for d := first; d.GetYear() == year; d = d.Next(1) {
+			if c.IsStatutoryHoliday(d) {
+				count++
+			}
+		}
+		if count != want {
+			t.Errorf("%d年 期望%d天, 实际%d天", year, want, count)
+		}
+	}
+	cases := []struct {
+		y, m, d   int
+		statutory bool
+	}{
+		{2026, 2, 16, true},  // 除夕
+		{2024, 2, 9, false},  // 2024年除夕不是法定假日
+		{2026, 2, 20, false}, // 春节假期中的调休
+		{2026, 4, 5, true},   // 清明
+		{2026, 5, 2, true},   // 劳动节第二天
+		{2026, 6, 19, true},  // 端午
+		{2026, 9, 25, true},  // 中秋
+		{2026, 10, 3, true},  // 国庆
+		{2026, 10, 4, false}, // 国庆假期中的调休
+		{2027, 1, 1, false},  // 还没有收录放假安排
+	}
+	for _, tc := range cases {
+		day, _ := festival.NewSolarDay(tc.y, tc.m, tc.d)
+		if got := c.IsStatutoryHoliday(day); got != tc.statutory {
+			t.Errorf("%d-%02d-%02d 期望 %v, 实际 %v", tc.y, tc.m, tc.d, tc.statutory, got)
+		}
+	}
+
+	// 自行添加的放假安排，法定假日必须在放假期间
+	start, _ := festival.NewSolarDay(2027, 1, 1)
+	h, _ := festival.NewHoliday("元旦", start, start.Next(2))
+	if _, err := h.WithStatutoryDays(start.Next(3)); err == nil {
+		t.Errorf("法定假日不在放假期间应报错")
+	}
+	h, err := h.WithStatutoryDays(start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Add(h)
+	if !c.IsStatutoryHoliday(start) || c.IsStatutoryHoliday(start.Next(1)) {
+		t.Errorf("期望只有2027年1月1日为法定假日")
+	}
+}
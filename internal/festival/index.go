@@ -0,0 +1,135 @@
+package festival
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ============ 年度索引 ============
+
+// yearIndex 某公历年的预计算结果，按当年第几天（从0开始）索引
+// 节气、朔日的天文计算只在建立索引时进行，之后农历日期、节气、节日查询都是查表
+type yearIndex struct {
+	first     int
+	lunar     []lunarDate
+	terms     map[int]SolarTerm
+	festivals [][]Festival
+	// seasons 内置时令（数九、三伏的第一天），不在festivals中，使用WithSeasons时才作为节日
+	seasons map[int]string
+}
+
+// lunarDate 农历年月日，month为负数表示闰月
+type lunarDate struct {
+	year  int
+	month int
+	day   int
+}
+
+// yearIndexEntry 年度索引项，保证每年只建立一次
+type yearIndexEntry struct {
+	once  sync.Once
+	index *yearIndex
+}
+
+// yearIndexes 年度索引，公历年 -> *yearIndexEntry
+var yearIndexes atomic.Pointer[sync.Map]
+
+func init() {
+	resetYearIndexes()
+}
+
+// resetYearIndexes 清空年度索引，节日数据集、日历参考时区变化后需要重建
+func resetYearIndexes() {
+	yearIndexes.Store(&sync.Map{})
+}
+
+// getYearIndex 获取某公历年的索引，首次使用时建立，可并发调用
+func getYearIndex(year int) *yearIndex {
+	e, _ := yearIndexes.Load().LoadOrStore(year, &yearIndexEntry{})
+	entry := e.(*yearIndexEntry)
+	entry.once.Do(func() {
+		entry.index = buildYearIndex(year)
+	})
+	return entry.index
+}
+
+// lookup 获取某天在索引中的位置
+func (o *yearIndex) lookup(d SolarDay) int {
+	return d.dayNumber() - o.first
+}
+
+// buildYearIndex 建立某公历年的索引
+func buildYearIndex(year int) *yearIndex {
+	day := SolarDay{year: year, month: 1, day: 1}
+	// 1582年10月少了10天，所以不按平年闰年计算天数
+	days := SolarDay{year: year + 1, month: 1, day: 1}.dayNumber() - day.dayNumber()
+	o := &yearIndex{
+		first:     day.dayNumber(),
+		lunar:     make([]lunarDate, days),
+		terms:     map[int]SolarTerm{},
+		festivals: make([][]Festival, days),
+		seasons:   map[int]string{},
+	}
+
+	// 农历日期：从1月1日所在农历月开始逐月推移
+	// 只为支持范围内的年份建立索引，出错说明历法数据有误
+	ld, err := day.computeLunarDay()
+	if err != nil {
+		panic(err)
+	}
+	m, d := ld.month, ld.day
+	for i := 0; i < days; i++ {
+		o.lunar[i] = lunarDate{year: m.GetYear(), month: m.GetMonthWithLeap(), day: d}
+		if d++; d > m.GetDayCount() {
+			m, d = m.Next(1), 1
+		}
+	}
+
+	// 节气：上一年冬至（索引0）之后的节气及下一年冬至，顺序同GetSolarTerm
+	for _, y := range []int{year, year + 1} {
+		for i := 0; i < 24; i++ {
+			term := NewSolarTermFromIndex(y, i)
+			k := o.lookup(term.GetSolarDay())
+			if _, ok := o.terms[k]; !ok && k >= 0 && k < days {
+				o.terms[k] = term
+			}
+		}
+	}
+
+	// 时令：数九、三伏的第一天
+	for _, y := range []int{year, year + 1} {
+		start := o.lookup(NewSolarTermFromIndex(y, 0).GetSolarDay())
+		for i, name := range ShuJiuNames {
+			o.seasons[start+9*i] = name
+		}
+	}
+	for i, start := range dogDayStarts(year) {
+		o.seasons[start-o.first] = DogDayNames[i]
+	}
+
+	// 节日，优先级同GetFestivals: 农历节日 > 公历节日 > 节气
+	dataset := CurrentDataset()
+	for i := 0; i < days; i++ {
+		var festivals []Festival
+		l := o.lunar[i]
+		term, isTerm := o.terms[i]
+		termIndex := -1
+		if isTerm {
+			termIndex = term.index % 24
+		}
+		for _, k := range dataset.lunarFestivals(l.year, l.month, l.day, termIndex) {
+			def := dataset.lunar[k]
+			festivals = append(festivals, Festival{Type: def.festivalType, Name: def.Name, SolarDay: day, Tags: def.Tags})
+		}
+		for _, k := range dataset.solarFestivals(day) {
+			def := dataset.solar[k]
+			festivals = append(festivals, Festival{Type: def.festivalType, Name: def.Name, SolarDay: day, Tags: def.Tags})
+		}
+		if isTerm {
+			festivals = append(festivals, Festival{Type: FestivalTypeSolarTerm, Name: term.GetName(), SolarDay: day})
+		}
+		o.festivals[i] = festivals
+		day = day.Next(1)
+	}
+	return o
+}
@@ -0,0 +1,92 @@
+package festival_test
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"workoff-timer/internal/festival"
+)
+
+// TestYearIndex 年度索引与逐日计算结果一致
+func TestYearIndex(t *testing.T) {
+	start, _ := festival.NewSolarDay(2015, 1, 1)
+	end, _ := festival.NewSolarDay(2031, 1, 1)
+	for d := start; !d.Equals(end); d = d.Next(1) {
+		ld, err := festival.ComputeLunarDay(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a := d.GetLunarDay(); a.String() != ld.String() {
+			t.Fatalf("%s 农历日不一致: %s %s", d, a, ld)
+		}
+		a := d.GetFestivals(festival.WithSeasons())
+		b := festival.ComputeFestivals(d)
+		if !slices.EqualFunc(a, b, func(x, y festival.Festival) bool { return x.String() == y.String() }) {
+			t.Fatalf("%s 节日不一致: %v %v", d, a, b)
+		}
+	}
+}
+
+// TestYearIndexConcurrent 并发建立、查询年度索引
+func TestYearIndexConcurrent(t *testing.T) {
+	festival.ResetYearIndexes()
+	day, _ := festival.NewSolarDay(2026, 9, 25)
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if f := day.GetNearestFestival(0); f == nil || f.Name != "中秋节" {
+				t.Errorf("期望中秋节, 实际 %v", f)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func BenchmarkGetNearestFestivals(b *testing.B) {
+	day, _ := festival.NewSolarDay(2026, 10, 18)
+	day.GetNearestFestivals(60)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		day.GetNearestFestivals(60, festival.WithSeasons())
+	}
+}
+
+// BenchmarkGetNearestFestivalsLegacy 建立索引前的逐日算法
+func BenchmarkGetNearestFestivalsLegacy(b *testing.B) {
+	day, _ := festival.NewSolarDay(2026, 10, 18)
+	for i := 0; i < b.N; i++ {
+		for j := 0; j <= 60; j++ {
+			if len(festival.ComputeFestivals(day.Next(j))) > 0 {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkGetLunarDay(b *testing.B) {
+	day, _ := festival.NewSolarDay(2026, 10, 18)
+	day.GetLunarDay()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		day.GetLunarDay()
+	}
+}
+
+func BenchmarkGetLunarDayLegacy(b *testing.B) {
+	day, _ := festival.NewSolarDay(2026, 10, 18)
+	for i := 0; i < b.N; i++ {
+		festival.ComputeLunarDay(day)
+	}
+}
+
+// BenchmarkBuildYearIndex 建立一年的索引
+func BenchmarkBuildYearIndex(b *testing.B) {
+	day, _ := festival.NewSolarDay(2026, 10, 18)
+	for i := 0; i < b.N; i++ {
+		festival.ResetYearIndexes()
+		day.GetLunarDay()
+	}
+}
@@ -3,8 +3,6 @@ package festival
 import (
 	"container/list"
 	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
 	"sync"
 )
@@ -22,8 +20,9 @@ type LunarYear struct {
 // NewLunarYear 创建农历年
 func NewLunarYear(year int) (LunarYear, error) {
 	initLunarYearLeap()
-	if year < -1 || year > 9999 {
-		return LunarYear{}, fmt.Errorf("非法农历年: %d", year)
+	// 比支持范围多两年（-1、0年）：农历年与公历年错开，计算公历1年年初的农历月要用到前面的农历年
+	if year < MinYear-2 || year > MaxYear {
+		return LunarYear{}, outOfRange(fmt.Sprintf("农历%d年", year))
 	}
 	return LunarYear{year: year}, nil
 }
@@ -75,8 +74,12 @@ func (o LunarYear) GetMonthCount() int {
 	return 13
 }
 
+// Next 推移n年，超出支持范围时返回零值
 func (o LunarYear) Next(n int) LunarYear {
-	y, _ := NewLunarYear(o.year + n)
+	y, err := NewLunarYear(o.year + n)
+	if err != nil {
+		return LunarYear{}
+	}
 	return y
 }
 
@@ -129,11 +132,11 @@ func NewLunarMonth(year int, month int) (LunarMonth, error) {
 		m = -m
 	}
 	if leap && m != currentLeapMonth {
-		return LunarMonth{}, fmt.Errorf("农历%d年没有闰%d月", year, m)
+		return LunarMonth{}, fmt.Errorf("农历%d年闰%d月: %w", year, m, ErrInvalidLeapMonth)
 	}
 
 	dongZhiJd := initTermByYear(year, 0)
-	w := CalcShuo(dongZhiJd)
+	w := calcShuo(dongZhiJd)
 	if w > dongZhiJd {
 		w -= 29.53
 	}
@@ -142,8 +145,8 @@ func NewLunarMonth(year int, month int) (LunarMonth, error) {
 	if year > 8 && year < 24 {
 		offset = 1
 	} else if year != 239 && year != 240 {
-		y, _ := NewLunarYear(year - 1)
-		if y.GetLeapMonth() > 10 {
+		// 前一年只用来查闰月，不受支持范围限制
+		if (LunarYear{year: year - 1}).GetLeapMonth() > 10 {
 			offset = 3
 		}
 	}
@@ -154,13 +157,13 @@ func NewLunarMonth(year int, month int) (LunarMonth, error) {
 	}
 
 	w += 29.5306 * float64(offset+index)
-	firstDay := CalcShuo(w)
+	firstDay := calcShuo(w)
 
 	result := LunarMonth{
 		year:           currentYear,
 		month:          m,
 		leap:           leap,
-		dayCount:       int(CalcShuo(w+29.5306) - firstDay),
+		dayCount:       int(calcShuo(w+29.5306) - firstDay),
 		indexInYear:    index,
 		firstJulianDay: NewJulianDay(J2000 + firstDay),
 	}
@@ -180,24 +183,28 @@ func (o LunarMonth) GetMonthWithLeap() int {
 	return o.month
 }
 
+// Next 推移n个月，超出支持范围时返回零值
 func (o LunarMonth) Next(n int) LunarMonth {
 	if n == 0 {
-		month, _ := NewLunarMonth(o.GetYear(), o.GetMonthWithLeap())
-		return month
+		return o
 	}
 	m := o.indexInYear + 1 + n
 	y := o.year
+	var err error
 	if n > 0 {
-		for m > y.GetMonthCount() {
+		for m > y.GetMonthCount() && err == nil {
 			m -= y.GetMonthCount()
-			y = y.Next(1)
+			y, err = NewLunarYear(y.GetYear() + 1)
 		}
 	} else {
-		for m <= 0 {
-			y = y.Next(-1)
+		for m <= 0 && err == nil {
+			y, err = NewLunarYear(y.GetYear() - 1)
 			m += y.GetMonthCount()
 		}
 	}
+	if err != nil {
+		return LunarMonth{}
+	}
 	leap := false
 	leapMonth := y.GetLeapMonth()
 	if leapMonth > 0 {
@@ -211,7 +218,10 @@ func (o LunarMonth) Next(n int) LunarMonth {
 	if leap {
 		m = -m
 	}
-	month, _ := NewLunarMonth(y.GetYear(), m)
+	month, err := NewLunarMonth(y.GetYear(), m)
+	if err != nil {
+		return LunarMonth{}
+	}
 	return month
 }
 
@@ -223,7 +233,7 @@ type LunarDay struct {
 	day   int
 }
 
-// NewLunarDay 创建农历日
+// NewLunarDay 创建农历日，对应的公历日超出支持范围时返回ErrOutOfRange，没有该闰月时返回ErrInvalidLeapMonth
 func NewLunarDay(year int, month int, day int) (LunarDay, error) {
 	m, err := NewLunarMonth(year, month)
 	if err != nil {
@@ -232,14 +242,21 @@ func NewLunarDay(year int, month int, day int) (LunarDay, error) {
 	if day < 1 || day > m.GetDayCount() {
 		return LunarDay{}, fmt.Errorf("非法农历日: %d年%d月%d日", year, month, day)
 	}
+	if n := int(m.GetFirstJulianDay().GetDay()) + day - 1; n < minDayNumber || n > maxDayNumber {
+		return LunarDay{}, outOfRange(fmt.Sprintf("农历%d年%d月%d日", year, month, day))
+	}
 	return LunarDay{month: m, day: day}, nil
 }
 
-func (o LunarDay) GetYear() int        { return o.month.GetYear() }
-func (o LunarDay) GetMonth() int       { return o.month.GetMonthWithLeap() }
-func (o LunarDay) GetMonthValue() int  { return o.month.GetMonth() }
-func (o LunarDay) GetDay() int         { return o.day }
-func (o LunarDay) Next(n int) LunarDay { return o.GetSolarDay().Next(n).GetLunarDay() }
+func (o LunarDay) GetYear() int       { return o.month.GetYear() }
+func (o LunarDay) GetMonth() int      { return o.month.GetMonthWithLeap() }
+func (o LunarDay) GetMonthValue() int { return o.month.GetMonth() }
+func (o LunarDay) GetDay() int        { return o.day }
+
+// Next 推移n天，超出支持范围时返回零值，需要区分时使用 LunarDayOf
+func (o LunarDay) Next(n int) LunarDay {
+	return o.GetSolarDay().Next(n).GetLunarDay()
+}
 
 // GetSolarDay 获取公历日
 func (o LunarDay) GetSolarDay() SolarDay {
@@ -249,52 +266,56 @@ func (o LunarDay) GetSolarDay() SolarDay {
 // ============ 农历节日 ============
 
 // LunarFestivalNames 农历节日名称
-var LunarFestivalNames = []string{"春节", "元宵节", "龙头节", "上巳节", "清明节", "端午节", "七夕节", "中元节", "中秋节", "重阳节", "冬至节", "腊八节", "除夕"}
+//
+// Deprecated: 节日定义已改为数据集，请使用 DefaultDataset().GetDefinitions()。此变量由内置数据集生成，修改它不影响节日查询
+var LunarFestivalNames = defaultDataset.legacyLunarNames()
 
 // LunarFestivalData 农历节日数据 格式: @索引类型(0=日期,1=节气,2=除夕)数据
-var LunarFestivalData = "@0000101@0100115@0200202@0300303@04107@0500505@0600707@0700715@0800815@0900909@10124@1101208@122"
+//
+// Deprecated: 同 LunarFestivalNames
+var LunarFestivalData = defaultDataset.legacyLunarData()
 
 // LunarFestival 农历节日
 type LunarFestival struct {
-	name string
+	index        int
+	name         string
+	festivalType FestivalTypeEnum
+	tags         []string
 }
 
-// GetLunarFestivalByYmd 根据农历年月日获取农历节日
+// GetLunarFestivalByYmd 根据农历年月日获取农历节日，同一天有多个时返回第一个
 func GetLunarFestivalByYmd(year int, month int, day int) (*LunarFestival, error) {
-	// 检查日期类型节日
-	re, _ := regexp.Compile(fmt.Sprintf("@\\d{2}0%02d%02d", month, day))
-	data := re.FindString(LunarFestivalData)
-	if data != "" {
-		index, _ := strconv.Atoi(data[1:3])
-		return &LunarFestival{name: LunarFestivalNames[index]}, nil
+	festivals, err := GetLunarFestivalsByYmd(year, month, day)
+	if err != nil || len(festivals) == 0 {
+		return nil, err
 	}
+	return &festivals[0], nil
+}
 
-	// 检查节气类型节日（清明节）
-	re, _ = regexp.Compile("@\\d{2}1\\d{2}")
-	arr := re.FindAllString(LunarFestivalData, -1)
-	for _, data := range arr {
-		i, _ := strconv.Atoi(data[4:])
-		solarTerm := NewSolarTermFromIndex(year, i)
-		d := solarTerm.GetSolarDay().GetLunarDay()
-		if d.GetYear() == year && d.GetMonth() == month && d.GetDay() == day {
-			index, _ := strconv.Atoi(data[1:3])
-			return &LunarFestival{name: LunarFestivalNames[index]}, nil
+// GetLunarFestivalsByYmd 根据农历年月日获取当天全部农历节日，按数据集中的顺序排列，month为负数表示闰月
+func GetLunarFestivalsByYmd(year int, month int, day int) ([]LunarFestival, error) {
+	// 节气规则按当天的节气匹配，农历日期不存在时只匹配日期规则
+	term := -1
+	if d, err := NewLunarDay(year, month, day); err == nil {
+		if t := d.GetSolarDay().GetSolarTerm(); t != nil {
+			term = t.GetIndex() % 24
 		}
 	}
-
-	// 检查除夕
-	d, err := NewLunarDay(year, month, day)
-	if err != nil {
-		return nil, nil
+	dataset := CurrentDataset()
+	var festivals []LunarFestival
+	for _, i := range dataset.lunarFestivals(year, month, day, term) {
+		def := dataset.lunar[i]
+		festivals = append(festivals, LunarFestival{index: i, name: def.Name, festivalType: def.festivalType, tags: def.Tags})
 	}
-	nextDay := d.Next(1)
-	if nextDay.GetMonthValue() == 1 && nextDay.GetDay() == 1 {
-		return &LunarFestival{name: "除夕"}, nil
-	}
-	return nil, nil
+	return festivals, nil
 }
 
 // GetName 获取名称
 func (o LunarFestival) GetName() string {
 	return o.name
 }
+
+// GetTags 获取标签
+func (o LunarFestival) GetTags() []string {
+	return o.tags
+}
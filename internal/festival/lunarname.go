@@ -0,0 +1,122 @@
+package festival
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ============ 农历名称 ============
+
+// LunarMonthNames 农历月名称
+var LunarMonthNames = []string{"正月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "冬月", "腊月"}
+
+// LunarDayNames 农历日名称
+var LunarDayNames = []string{"初一", "初二", "初三", "初四", "初五", "初六", "初七", "初八", "初九", "初十", "十一", "十二", "十三", "十四", "十五", "十六", "十七", "十八", "十九", "二十", "廿一", "廿二", "廿三", "廿四", "廿五", "廿六", "廿七", "廿八", "廿九", "三十"}
+
+// lunarYearDigits 农历年数字
+var lunarYearDigits = []string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}
+
+// GetName 获取年名称，如“二〇二六”
+func (o LunarYear) GetName() string {
+	year := o.year
+	prefix := ""
+	if year < 0 {
+		prefix = "前"
+		year = -year
+	}
+	var b strings.Builder
+	b.WriteString(prefix)
+	for _, c := range []byte(strconv.Itoa(year)) {
+		b.WriteString(lunarYearDigits[c-'0'])
+	}
+	return b.String()
+}
+
+// String 字符串表示，如“农历二〇二六年”
+func (o LunarYear) String() string {
+	return o.Format("农历%Y年")
+}
+
+// Format 格式化，支持%Y(年名称)、%G(年干支)、%%
+func (o LunarYear) Format(layout string) string {
+	return formatLunar(layout, o, nil, 0)
+}
+
+// IsLeap 是否闰月
+func (o LunarMonth) IsLeap() bool { return o.leap }
+
+// IsBig 是否大月（30天）
+func (o LunarMonth) IsBig() bool { return o.dayCount == 30 }
+
+// GetName 获取月名称，如“正月”、“闰六月”、“腊月”
+func (o LunarMonth) GetName() string {
+	name := LunarMonthNames[o.month-1]
+	if o.leap {
+		return "闰" + name
+	}
+	return name
+}
+
+// GetSizeName 获取大小月名称，大月为“大”，小月为“小”
+func (o LunarMonth) GetSizeName() string {
+	if o.IsBig() {
+		return "大"
+	}
+	return "小"
+}
+
+// String 字符串表示，如“农历二〇二六年腊月”
+func (o LunarMonth) String() string {
+	return o.Format("农历%Y年%M")
+}
+
+// Format 格式化，在LunarYear.Format基础上支持%M(月名称)、%S(大小月)
+func (o LunarMonth) Format(layout string) string {
+	return formatLunar(layout, o.year, &o, 0)
+}
+
+// GetName 获取日名称，如“初一”、“廿三”
+func (o LunarDay) GetName() string {
+	return LunarDayNames[o.day-1]
+}
+
+// String 字符串表示，如“农历二〇二六年腊月廿三”
+func (o LunarDay) String() string {
+	return o.Format("农历%Y年%M%D")
+}
+
+// Format 格式化，在LunarMonth.Format基础上支持%D(日名称)
+// 如Format("%M%S")得到“十月大”，Format("%M%D")得到“闰六月初一”
+func (o LunarDay) Format(layout string) string {
+	return formatLunar(layout, o.month.year, &o.month, o.day)
+}
+
+// formatLunar 按布局格式化农历日期，month为nil或day为0时对应占位符原样保留
+func formatLunar(layout string, year LunarYear, month *LunarMonth, day int) string {
+	var b strings.Builder
+	for i := 0; i < len(layout); i++ {
+		if layout[i] != '%' || i+1 == len(layout) {
+			b.WriteByte(layout[i])
+			continue
+		}
+		i++
+		switch c := layout[i]; {
+		case c == '%':
+			b.WriteByte('%')
+		case c == 'Y':
+			b.WriteString(year.GetName())
+		case c == 'G':
+			b.WriteString(year.GetSixtyCycle().GetName())
+		case c == 'M' && month != nil:
+			b.WriteString(month.GetName())
+		case c == 'S' && month != nil:
+			b.WriteString(month.GetSizeName())
+		case c == 'D' && day > 0:
+			b.WriteString(LunarDayNames[day-1])
+		default:
+			b.WriteByte('%')
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,241 @@
+package festival
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ============ 个人纪念日 ============
+
+// PersonalEventKind 个人纪念日类型
+type PersonalEventKind int
+
+const (
+	// PersonalEventBirthday 生日，如“妈妈 60 岁生日”
+	PersonalEventBirthday PersonalEventKind = iota
+	// PersonalEventAnniversary 周年纪念日，如“结婚 5 周年”
+	PersonalEventAnniversary
+)
+
+// AgeStyle 生日的年龄算法
+type AgeStyle int
+
+const (
+	// AgeStyleFull 周岁，出生当天为0岁，每过一次生日加1岁
+	AgeStyleFull AgeStyle = iota
+	// AgeStyleNominal 虚岁，出生即1岁，每过一个农历新年加1岁
+	AgeStyleNominal
+)
+
+// PersonalEvent 个人纪念日（生日、结婚纪念日、入职纪念日等），每年按公历或农历同一月日重复
+type PersonalEvent struct {
+	name     string
+	kind     PersonalEventKind
+	origin   SolarDay
+	lunar    *LunarRecurrence
+	ageStyle AgeStyle
+}
+
+// NewSolarPersonalEvent 创建按公历过的个人纪念日，2月29日在平年按2月28日过
+// name: 名称，如“妈妈”、“结婚”; origin: 出生或纪念的公历日
+func NewSolarPersonalEvent(name string, kind PersonalEventKind, origin SolarDay) PersonalEvent {
+	return PersonalEvent{name: name, kind: kind, origin: origin}
+}
+
+// NewLunarPersonalEvent 创建按农历过的个人纪念日，闰月、小月的处理见 LunarRecurrence
+// name: 名称，如“妈妈”、“结婚”; origin: 出生或纪念的农历日
+func NewLunarPersonalEvent(name string, kind PersonalEventKind, origin LunarDay) PersonalEvent {
+	r := NewLunarRecurrenceFromLunarDay(origin)
+	return PersonalEvent{name: name, kind: kind, origin: origin.GetSolarDay(), lunar: &r}
+}
+
+// WithAgeStyle 设置生日的年龄算法，默认周岁
+func (o PersonalEvent) WithAgeStyle(s AgeStyle) PersonalEvent {
+	o.ageStyle = s
+	return o
+}
+
+// WithRecurrence 设置农历纪念日的闰月、缺日处理策略，公历纪念日忽略
+func (o PersonalEvent) WithRecurrence(r LunarRecurrence) PersonalEvent {
+	if o.lunar != nil {
+		o.lunar = &r
+	}
+	return o
+}
+
+func (o PersonalEvent) GetName() string            { return o.name }
+func (o PersonalEvent) GetKind() PersonalEventKind { return o.kind }
+func (o PersonalEvent) GetOrigin() SolarDay        { return o.origin }
+func (o PersonalEvent) GetAgeStyle() AgeStyle      { return o.ageStyle }
+func (o PersonalEvent) IsLunar() bool              { return o.lunar != nil }
+
+// occurrence 获取第years次周年对应的公历日，当年不出现时返回false
+func (o PersonalEvent) occurrence(years int) (SolarDay, bool) {
+	if o.lunar != nil {
+		return o.lunar.GetSolarDayInYear(o.origin.GetLunarDay().GetYear() + years)
+	}
+	year, month, day := o.origin.year+years, o.origin.month, o.origin.day
+	if day > GetSolarMonthDays(year, month) {
+		day = GetSolarMonthDays(year, month)
+	}
+	return SolarDay{year: year, month: month, day: day}, true
+}
+
+// years 获取指定日期是第几次周年，不是周年当天返回false
+func (o PersonalEvent) years(d SolarDay) (int, bool) {
+	years := d.year - o.origin.year
+	if o.lunar != nil {
+		years = d.GetLunarDay().GetYear() - o.origin.GetLunarDay().GetYear()
+	}
+	// 农历腊月的纪念日可能顺延到下一农历年，所以也检查上一次
+	for _, n := range []int{years, years - 1} {
+		if n < 1 {
+			continue
+		}
+		if day, ok := o.occurrence(n); ok && day.Equals(d) {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// GetOrdinal 获取指定日期对应的序数（生日为岁数，纪念日为周年数），不是周年当天返回false
+func (o PersonalEvent) GetOrdinal(d SolarDay) (int, bool) {
+	years, ok := o.years(d)
+	if !ok {
+		return 0, false
+	}
+	return o.ordinal(d, years), true
+}
+
+// ordinal 第years次周年（公历日d）的序数
+func (o PersonalEvent) ordinal(d SolarDay, years int) int {
+	if o.kind == PersonalEventBirthday && o.ageStyle == AgeStyleNominal {
+		return d.GetLunarDay().GetYear() - o.origin.GetLunarDay().GetYear() + 1
+	}
+	return years
+}
+
+// GetTitle 获取指定序数的显示名称，如“妈妈 60 岁生日”、“结婚 5 周年”
+func (o PersonalEvent) GetTitle(ordinal int) string {
+	if o.kind == PersonalEventBirthday {
+		return fmt.Sprintf("%s %d 岁生日", o.name, ordinal)
+	}
+	return fmt.Sprintf("%s %d 周年", o.name, ordinal)
+}
+
+// Next 获取从指定公历日起（含当天）的下一次周年及其序数，找不到时返回false
+func (o PersonalEvent) Next(from SolarDay) (SolarDay, int, bool) {
+	n := from.dayNumber()
+	start := from.year - o.origin.year - 1
+	if o.lunar != nil {
+		start = from.GetLunarDay().GetYear() - o.origin.GetLunarDay().GetYear() - 1
+	}
+	start = max(start, 1)
+	for years := start; years <= start+maxRecurrenceYears; years++ {
+		if d, ok := o.occurrence(years); ok && d.dayNumber() >= n {
+			return d, o.ordinal(d, years), true
+		}
+	}
+	return SolarDay{}, 0, false
+}
+
+// getPersonalFestivals 获取当天的个人纪念日
+func (o SolarDay) getPersonalFestivals(events []PersonalEvent) []Festival {
+	var festivals []Festival
+	for _, e := range events {
+		if ordinal, ok := e.GetOrdinal(o); ok {
+			festivals = append(festivals, Festival{Type: FestivalTypePersonal, Name: e.GetTitle(ordinal), SolarDay: o})
+		}
+	}
+	return festivals
+}
+
+// ============ 个人纪念日文件 ============
+
+// PersonalEventDefinition 个人纪念日定义（数据文件中的一条记录）
+type PersonalEventDefinition struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Calendar string `json:"calendar"`
+	Year     int    `json:"year"`
+	Month    int    `json:"month"`
+	Day      int    `json:"day"`
+	Age      string `json:"age,omitempty"`
+}
+
+// personalEventKinds 数据文件中kind字段的取值
+var personalEventKinds = map[string]PersonalEventKind{
+	"birthday":    PersonalEventBirthday,
+	"anniversary": PersonalEventAnniversary,
+}
+
+// ageStyles 数据文件中age字段的取值
+var ageStyles = map[string]AgeStyle{
+	"":        AgeStyleFull,
+	"full":    AgeStyleFull,
+	"nominal": AgeStyleNominal,
+}
+
+// LoadPersonalEvents 从JSON读取个人纪念日，格式与节日数据集相同，记录放在events数组中
+// 农历闰月的month为负数
+func LoadPersonalEvents(source string, r io.Reader) ([]PersonalEvent, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	p := newDatasetParser(source, data)
+	var events []PersonalEvent
+	err = p.parse(map[string]func(json.RawMessage, int64) error{
+		"events": func(raw json.RawMessage, base int64) error {
+			var def PersonalEventDefinition
+			if err := p.decodeElement(raw, base, &def); err != nil {
+				return err
+			}
+			e, field, err := compilePersonalEvent(def)
+			if err != nil {
+				return p.errorAt(base+fieldOffset(raw, field), fmt.Errorf("%s: %w", def.Name, err))
+			}
+			events = append(events, e)
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// compilePersonalEvent 校验个人纪念日定义，出错时返回出错的字段路径
+func compilePersonalEvent(def PersonalEventDefinition) (PersonalEvent, []string, error) {
+	if def.Name == "" {
+		return PersonalEvent{}, []string{"name"}, fmt.Errorf("缺少名称")
+	}
+	kind, ok := personalEventKinds[def.Kind]
+	if !ok {
+		return PersonalEvent{}, []string{"kind"}, fmt.Errorf("非法纪念日类型: %q，应为birthday或anniversary", def.Kind)
+	}
+	age, ok := ageStyles[def.Age]
+	if !ok {
+		return PersonalEvent{}, []string{"age"}, fmt.Errorf("非法年龄算法: %q，应为full或nominal", def.Age)
+	}
+	var e PersonalEvent
+	switch def.Calendar {
+	case CalendarSolar:
+		d, err := NewSolarDay(def.Year, def.Month, def.Day)
+		if err != nil {
+			return PersonalEvent{}, []string{"day"}, err
+		}
+		e = NewSolarPersonalEvent(def.Name, kind, d)
+	case CalendarLunar:
+		d, err := NewLunarDay(def.Year, def.Month, def.Day)
+		if err != nil {
+			return PersonalEvent{}, []string{"day"}, err
+		}
+		e = NewLunarPersonalEvent(def.Name, kind, d)
+	default:
+		return PersonalEvent{}, []string{"calendar"}, fmt.Errorf("非法历法: %q，应为%q或%q", def.Calendar, CalendarSolar, CalendarLunar)
+	}
+	return e.WithAgeStyle(age), nil, nil
+}
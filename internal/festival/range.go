@@ -0,0 +1,63 @@
+package festival
+
+import (
+	"iter"
+	"time"
+)
+
+// ============ 节日遍历 ============
+
+// Festivals 遍历start至end（含）之间的节日，按日期先后、同一天按GetFestivals的优先级依次产出
+// end早于start时按日期倒序遍历
+func Festivals(start SolarDay, end SolarDay, opts ...FestivalOption) iter.Seq[Festival] {
+	return func(yield func(Festival) bool) {
+		step := 1
+		days := end.dayNumber() - start.dayNumber()
+		if days < 0 {
+			step = -1
+			days = -days
+		}
+		for i := 0; i <= days; i++ {
+			for _, f := range start.Next(i * step).GetFestivals(opts...) {
+				if !yield(f) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// GetPreviousFestivals 获取之前最近有节日的那一天的全部节日（向前查找，含当天）
+// maxDays: 最大查找天数
+func (o SolarDay) GetPreviousFestivals(maxDays int, opts ...FestivalOption) []Festival {
+	for i := 0; i <= maxDays; i++ {
+		if festivals := o.Next(-i).GetFestivals(opts...); len(festivals) > 0 {
+			return festivals
+		}
+	}
+	return nil
+}
+
+// GetPreviousFestival 获取之前最近的节日（向前查找，含当天），同一天有多个节日时返回优先级最高的一个
+// maxDays: 最大查找天数
+func (o SolarDay) GetPreviousFestival(maxDays int, opts ...FestivalOption) *Festival {
+	if festivals := o.GetPreviousFestivals(maxDays, opts...); len(festivals) > 0 {
+		return &festivals[0]
+	}
+	return nil
+}
+
+// DaysSince 距离节日已过去的天数，节日在当天之后时为负数
+func (f Festival) DaysSince(day SolarDay) int {
+	return day.dayNumber() - f.SolarDay.dayNumber()
+}
+
+// DaysUntil 距离节日还有几天（只比较日期，见 SolarDay.DaysUntil），节日在当天之前时为负数
+func (f Festival) DaysUntil(day SolarDay) int {
+	return day.DaysUntil(f.SolarDay)
+}
+
+// DurationUntil 从当前时刻（含时分秒）到节日当天零点的精确时长
+func (f Festival) DurationUntil(now SolarDay) time.Duration {
+	return now.DurationUntil(f.SolarDay)
+}
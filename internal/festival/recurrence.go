@@ -0,0 +1,133 @@
+package festival
+
+import "fmt"
+
+// ============ 农历周年 ============
+
+// LeapMonthPolicy 闰月纪念日的处理策略
+type LeapMonthPolicy int
+
+const (
+	// LeapMonthFallback 当年有该闰月时按闰月，否则按同名平月
+	LeapMonthFallback LeapMonthPolicy = iota
+	// LeapMonthStrict 只在有该闰月的年份出现
+	LeapMonthStrict
+)
+
+// MissingDayPolicy 当月没有该日（如小月没有三十）时的处理策略
+type MissingDayPolicy int
+
+const (
+	// MissingDayLastDay 提前到当月最后一天
+	MissingDayLastDay MissingDayPolicy = iota
+	// MissingDayNextDay 顺延到下月初一
+	MissingDayNextDay
+	// MissingDaySkip 当年不出现
+	MissingDaySkip
+)
+
+// maxRecurrenceYears 查找下一次周年的最大年数，闰月纪念日按LeapMonthStrict处理时可能数十年才出现一次
+const maxRecurrenceYears = 300
+
+// LunarRecurrence 农历周年（如农历生日），每年同一农历月日重复
+type LunarRecurrence struct {
+	month            int
+	day              int
+	leapMonthPolicy  LeapMonthPolicy
+	missingDayPolicy MissingDayPolicy
+}
+
+// NewLunarRecurrence 创建农历周年
+// month: 农历月，闰月为负数; day: 农历日(1-30)
+func NewLunarRecurrence(month int, day int) (LunarRecurrence, error) {
+	if month == 0 || month > 12 || month < -12 {
+		return LunarRecurrence{}, fmt.Errorf("非法农历月: %d", month)
+	}
+	if day < 1 || day > 30 {
+		return LunarRecurrence{}, fmt.Errorf("非法农历日: %d", day)
+	}
+	return LunarRecurrence{month: month, day: day}, nil
+}
+
+// NewLunarRecurrenceFromLunarDay 根据农历日创建农历周年
+func NewLunarRecurrenceFromLunarDay(d LunarDay) LunarRecurrence {
+	return LunarRecurrence{month: d.GetMonth(), day: d.GetDay()}
+}
+
+// WithLeapMonthPolicy 设置闰月处理策略
+func (o LunarRecurrence) WithLeapMonthPolicy(p LeapMonthPolicy) LunarRecurrence {
+	o.leapMonthPolicy = p
+	return o
+}
+
+// WithMissingDayPolicy 设置缺日处理策略
+func (o LunarRecurrence) WithMissingDayPolicy(p MissingDayPolicy) LunarRecurrence {
+	o.missingDayPolicy = p
+	return o
+}
+
+func (o LunarRecurrence) GetMonth() int { return o.month }
+func (o LunarRecurrence) GetDay() int   { return o.day }
+
+// GetSolarDayInYear 获取农历某年的周年对应的公历日，当年不出现时返回false
+func (o LunarRecurrence) GetSolarDayInYear(year int) (SolarDay, bool) {
+	month := o.month
+	if month < 0 {
+		y, err := NewLunarYear(year)
+		if err != nil {
+			return SolarDay{}, false
+		}
+		if y.GetLeapMonth() != -month {
+			if o.leapMonthPolicy == LeapMonthStrict {
+				return SolarDay{}, false
+			}
+			month = -month
+		}
+	}
+	m, err := NewLunarMonth(year, month)
+	if err != nil {
+		return SolarDay{}, false
+	}
+	day := o.day
+	if day > m.GetDayCount() {
+		switch o.missingDayPolicy {
+		case MissingDaySkip:
+			return SolarDay{}, false
+		case MissingDayNextDay:
+			return m.Next(1).GetFirstJulianDay().GetSolarDay(), true
+		default:
+			day = m.GetDayCount()
+		}
+	}
+	return m.GetFirstJulianDay().Next(day - 1).GetSolarDay(), true
+}
+
+// Next 获取从指定公历日起（含当天）的下一次周年，找不到时返回false
+func (o LunarRecurrence) Next(from SolarDay) (SolarDay, bool) {
+	n := from.dayNumber()
+	// 上一农历年的腊月三十可能顺延到本年正月初一，所以从上一年开始找
+	start := from.GetLunarDay().GetYear() - 1
+	for year := start; year <= start+maxRecurrenceYears; year++ {
+		if d, ok := o.GetSolarDayInYear(year); ok && d.dayNumber() >= n {
+			return d, true
+		}
+	}
+	return SolarDay{}, false
+}
+
+// String 字符串表示，如“八月十五”、“闰四月初五”
+func (o LunarRecurrence) String() string {
+	name := LunarMonthNames[abs(o.month)-1]
+	if o.month < 0 {
+		name = "闰" + name
+	}
+	return name + LunarDayNames[o.day-1]
+}
+
+// abs 绝对值
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
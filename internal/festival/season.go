@@ -0,0 +1,86 @@
+package festival
+
+import "fmt"
+
+// ============ 数九 ============
+
+// ShuJiuNames 数九名称
+var ShuJiuNames = []string{"一九", "二九", "三九", "四九", "五九", "六九", "七九", "八九", "九九"}
+
+// ShuJiuDay 数九天，从冬至起每九天为一九，共九九八十一天
+type ShuJiuDay struct {
+	index    int
+	dayIndex int
+}
+
+func (o ShuJiuDay) GetIndex() int    { return o.index }
+func (o ShuJiuDay) GetName() string  { return ShuJiuNames[o.index] }
+func (o ShuJiuDay) GetDayIndex() int { return o.dayIndex }
+
+// String 字符串表示，如“三九 第4天”
+func (o ShuJiuDay) String() string {
+	return fmt.Sprintf("%s 第%d天", o.GetName(), o.dayIndex+1)
+}
+
+// GetShuJiu 获取数九天，不在数九期间返回nil
+func (o SolarDay) GetShuJiu() *ShuJiuDay {
+	n := o.dayNumber()
+	// 当年冬至或上一年冬至
+	start := NewSolarTermFromIndex(o.year+1, 0).GetSolarDay().dayNumber()
+	if n < start {
+		start = NewSolarTermFromIndex(o.year, 0).GetSolarDay().dayNumber()
+	}
+	days := n - start
+	if days < 0 || days >= 81 {
+		return nil
+	}
+	return &ShuJiuDay{index: days / 9, dayIndex: days % 9}
+}
+
+// ============ 三伏 ============
+
+// DogDayNames 三伏名称
+var DogDayNames = []string{"初伏", "中伏", "末伏"}
+
+// DogDay 三伏天
+// 夏至后第3个庚日入初伏，第4个庚日入中伏，立秋后第1个庚日入末伏，初伏、末伏各10天，中伏10天或20天
+type DogDay struct {
+	index    int
+	dayIndex int
+	dayCount int
+}
+
+func (o DogDay) GetIndex() int    { return o.index }
+func (o DogDay) GetName() string  { return DogDayNames[o.index] }
+func (o DogDay) GetDayIndex() int { return o.dayIndex }
+func (o DogDay) GetDayCount() int { return o.dayCount }
+
+// String 字符串表示，如“中伏 第7天”
+func (o DogDay) String() string {
+	return fmt.Sprintf("%s 第%d天", o.GetName(), o.dayIndex+1)
+}
+
+// GetDogDay 获取三伏天，不在三伏期间返回nil
+func (o SolarDay) GetDogDay() *DogDay {
+	n := o.dayNumber()
+	starts := dogDayStarts(o.year)
+	// 初伏、中伏、末伏的起始日，以及出伏日
+	bounds := []int{starts[0], starts[1], starts[2], starts[2] + 10}
+	for i := 0; i < 3; i++ {
+		if n >= bounds[i] && n < bounds[i+1] {
+			return &DogDay{index: i, dayIndex: n - bounds[i], dayCount: bounds[i+1] - bounds[i]}
+		}
+	}
+	return nil
+}
+
+// dogDayStarts 计算某年初伏、中伏、末伏的起始日期序号
+func dogDayStarts(year int) [3]int {
+	xiaZhi := NewSolarTermFromIndex(year, 12).GetSolarDay()
+	liQiu := NewSolarTermFromIndex(year, 15).GetSolarDay()
+	// 庚为第7个天干（索引6），当天是庚日也算
+	first := xiaZhi.dayNumber() + cycleIndex(6-xiaZhi.GetDaySixtyCycle().GetHeavenStem().GetIndex(), 10)
+	chuFu := first + 20
+	moFu := liQiu.dayNumber() + cycleIndex(6-liQiu.GetDaySixtyCycle().GetHeavenStem().GetIndex(), 10)
+	return [3]int{chuFu, chuFu + 10, moFu}
+}
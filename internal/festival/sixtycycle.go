@@ -0,0 +1,209 @@
+package festival
+
+import "fmt"
+
+// ============ 五行 ============
+
+// ElementNames 五行名称
+var ElementNames = []string{"木", "火", "土", "金", "水"}
+
+// Element 五行
+type Element struct {
+	index int
+}
+
+// NewElement 根据索引创建五行
+func NewElement(index int) Element {
+	return Element{index: cycleIndex(index, len(ElementNames))}
+}
+
+func (o Element) GetIndex() int   { return o.index }
+func (o Element) GetName() string { return ElementNames[o.index] }
+func (o Element) String() string  { return o.GetName() }
+
+// ============ 天干 ============
+
+// HeavenStemNames 天干名称
+var HeavenStemNames = []string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+
+// HeavenStem 天干
+type HeavenStem struct {
+	index int
+}
+
+// NewHeavenStem 根据索引创建天干
+func NewHeavenStem(index int) HeavenStem {
+	return HeavenStem{index: cycleIndex(index, len(HeavenStemNames))}
+}
+
+func (o HeavenStem) GetIndex() int                 { return o.index }
+func (o HeavenStem) GetName() string               { return HeavenStemNames[o.index] }
+func (o HeavenStem) String() string                { return o.GetName() }
+func (o HeavenStem) Next(n int) HeavenStem         { return NewHeavenStem(o.index + n) }
+func (o HeavenStem) Equals(target HeavenStem) bool { return o.index == target.index }
+
+// GetElement 获取五行（甲乙木、丙丁火、戊己土、庚辛金、壬癸水）
+func (o HeavenStem) GetElement() Element {
+	return NewElement(o.index / 2)
+}
+
+// ============ 地支 ============
+
+// EarthBranchNames 地支名称
+var EarthBranchNames = []string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+
+// earthBranchElements 地支五行索引
+var earthBranchElements = []int{4, 2, 0, 0, 2, 1, 1, 2, 3, 3, 2, 4}
+
+// EarthBranch 地支
+type EarthBranch struct {
+	index int
+}
+
+// NewEarthBranch 根据索引创建地支
+func NewEarthBranch(index int) EarthBranch {
+	return EarthBranch{index: cycleIndex(index, len(EarthBranchNames))}
+}
+
+func (o EarthBranch) GetIndex() int                  { return o.index }
+func (o EarthBranch) GetName() string                { return EarthBranchNames[o.index] }
+func (o EarthBranch) String() string                 { return o.GetName() }
+func (o EarthBranch) Next(n int) EarthBranch         { return NewEarthBranch(o.index + n) }
+func (o EarthBranch) Equals(target EarthBranch) bool { return o.index == target.index }
+
+// GetElement 获取五行
+func (o EarthBranch) GetElement() Element {
+	return NewElement(earthBranchElements[o.index])
+}
+
+// ============ 六十甲子 ============
+
+// SoundNames 纳音名称
+var SoundNames = []string{"海中金", "炉中火", "大林木", "路旁土", "剑锋金", "山头火", "涧下水", "城头土", "白蜡金", "杨柳木", "泉中水", "屋上土", "霹雳火", "松柏木", "长流水", "沙中金", "山下火", "平地木", "壁上土", "金箔金", "覆灯火", "天河水", "大驿土", "钗钏金", "桑柘木", "大溪水", "沙中土", "天上火", "石榴木", "大海水"}
+
+// SixtyCycle 六十甲子（干支）
+type SixtyCycle struct {
+	index int
+}
+
+// NewSixtyCycle 根据索引创建干支，0为甲子
+func NewSixtyCycle(index int) SixtyCycle {
+	return SixtyCycle{index: cycleIndex(index, 60)}
+}
+
+// NewSixtyCycleFromStemBranch 根据天干地支创建干支
+func NewSixtyCycleFromStemBranch(stem HeavenStem, branch EarthBranch) (SixtyCycle, error) {
+	if stem.index%2 != branch.index%2 {
+		return SixtyCycle{}, fmt.Errorf("非法干支: %s%s", stem, branch)
+	}
+	return NewSixtyCycle(6*stem.index - 5*branch.index), nil
+}
+
+// NewSixtyCycleFromName 根据名称创建干支，如“甲子”
+func NewSixtyCycleFromName(name string) (SixtyCycle, error) {
+	for i := 0; i < 60; i++ {
+		if c := NewSixtyCycle(i); c.GetName() == name {
+			return c, nil
+		}
+	}
+	return SixtyCycle{}, fmt.Errorf("非法干支: %s", name)
+}
+
+func (o SixtyCycle) GetIndex() int                 { return o.index }
+func (o SixtyCycle) GetHeavenStem() HeavenStem     { return NewHeavenStem(o.index % 10) }
+func (o SixtyCycle) GetEarthBranch() EarthBranch   { return NewEarthBranch(o.index % 12) }
+func (o SixtyCycle) Next(n int) SixtyCycle         { return NewSixtyCycle(o.index + n) }
+func (o SixtyCycle) Equals(target SixtyCycle) bool { return o.index == target.index }
+
+// GetName 获取名称，如“甲子”
+func (o SixtyCycle) GetName() string {
+	return o.GetHeavenStem().GetName() + o.GetEarthBranch().GetName()
+}
+
+// String 字符串表示
+func (o SixtyCycle) String() string {
+	return o.GetName()
+}
+
+// GetSound 获取纳音，如甲子、乙丑为海中金
+func (o SixtyCycle) GetSound() string {
+	return SoundNames[o.index/2]
+}
+
+// cycleIndex 循环索引，保证结果在[0, size)之间
+func cycleIndex(index int, size int) int {
+	i := index % size
+	if i < 0 {
+		i += size
+	}
+	return i
+}
+
+// ============ 年月日时干支 ============
+
+// GetSixtyCycle 获取年干支（以正月初一为界）
+func (o LunarYear) GetSixtyCycle() SixtyCycle {
+	return NewSixtyCycle(o.year - 4)
+}
+
+// GetSixtyCycle 获取月干支（以农历月为界，正月建寅，闰月同本月）
+func (o LunarMonth) GetSixtyCycle() SixtyCycle {
+	return monthSixtyCycle(o.year.GetSixtyCycle().GetHeavenStem(), o.month-1)
+}
+
+// GetSixtyCycle 获取日干支
+func (o LunarDay) GetSixtyCycle() SixtyCycle {
+	return o.GetSolarDay().GetDaySixtyCycle()
+}
+
+// GetYearSixtyCycle 获取年干支（以立春为界）
+func (o SolarDay) GetYearSixtyCycle() SixtyCycle {
+	year := o.year
+	if o.dayNumber() < NewSolarTermFromIndex(year, 3).GetSolarDay().dayNumber() {
+		year--
+	}
+	return NewSixtyCycle(year - 4)
+}
+
+// GetMonthSixtyCycle 获取月干支（以节令为界，立春起寅月，惊蛰起卯月，依此类推）
+func (o SolarDay) GetMonthSixtyCycle() SixtyCycle {
+	n := o.dayNumber()
+	// 从当年大雪往前找最近的“节”（节气表中奇数索引为节）
+	index := 23
+	for NewSolarTermFromIndex(o.year, index).GetSolarDay().dayNumber() > n {
+		index -= 2
+	}
+	// 立春(3)为寅月，每个节后移一个月
+	offset := cycleIndex((index-3)/2, 12)
+	return monthSixtyCycle(o.GetYearSixtyCycle().GetHeavenStem(), offset)
+}
+
+// GetDaySixtyCycle 获取日干支
+func (o SolarDay) GetDaySixtyCycle() SixtyCycle {
+	return NewSixtyCycle(o.dayNumber() + 49)
+}
+
+// GetHourSixtyCycle 获取时干支，23点起为次日子时
+func (o SolarDay) GetHourSixtyCycle() SixtyCycle {
+	day := o.GetDaySixtyCycle()
+	if o.hour >= 23 {
+		day = day.Next(1)
+	}
+	branch := NewEarthBranch((o.hour + 1) / 2)
+	stem := NewHeavenStem(day.GetHeavenStem().GetIndex()%5*2 + branch.GetIndex())
+	c, _ := NewSixtyCycleFromStemBranch(stem, branch)
+	return c
+}
+
+// monthSixtyCycle 根据年干和距寅月的偏移计算月干支（甲己之年丙作首）
+func monthSixtyCycle(yearStem HeavenStem, offset int) SixtyCycle {
+	stem := NewHeavenStem(yearStem.GetIndex()%5*2 + 2 + offset)
+	branch := NewEarthBranch(2 + offset)
+	c, _ := NewSixtyCycleFromStemBranch(stem, branch)
+	return c
+}
+
+// dayNumber 获取日期序号（当天正午的儒略日数），用于只比较日期
+func (o SolarDay) dayNumber() int {
+	return int(JulianDayFromYmdHms(o.year, o.month, o.day, 12, 0, 0).GetDay())
+}
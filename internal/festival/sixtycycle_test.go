@@ -0,0 +1,64 @@
+package festival_test
+
+import (
+	"testing"
+	"time"
+
+	"workoff-timer/internal/festival"
+)
+
+// TestSixtyCycle 干支测试
+func TestSixtyCycle(t *testing.T) {
+	day, _ := festival.NewSolarDay(2000, 1, 1)
+	if c := day.GetDaySixtyCycle(); c.GetName() != "戊午" {
+		t.Errorf("2000年1月1日期望戊午日, 实际 %s", c)
+	}
+
+	// 年柱以立春为界，农历年以正月初一为界
+	before, _ := festival.NewSolarDay(2025, 2, 2)
+	after, _ := festival.NewSolarDay(2025, 2, 3)
+	if before.GetYearSixtyCycle().GetName() != "甲辰" || after.GetYearSixtyCycle().GetName() != "乙巳" {
+		t.Errorf("立春前后年柱错误: %s %s", before.GetYearSixtyCycle(), after.GetYearSixtyCycle())
+	}
+	if c := before.GetLunarDay(); c.GetYear() != 2025 {
+		t.Errorf("2025年2月2日应为农历乙巳年")
+	}
+	year, _ := festival.NewLunarYear(2025)
+	if c := year.GetSixtyCycle(); c.GetName() != "乙巳" || c.GetSound() != "覆灯火" {
+		t.Errorf("农历2025年期望乙巳覆灯火, 实际 %s%s", c, c.GetSound())
+	}
+
+	// 月柱以节为界
+	cases := []struct {
+		y, m, d int
+		name    string
+	}{
+		{2026, 2, 16, "庚寅"},
+		{2025, 1, 3, "丙子"},
+		{2025, 1, 10, "丁丑"},
+	}
+	for _, c := range cases {
+		d, _ := festival.NewSolarDay(c.y, c.m, c.d)
+		if got := d.GetMonthSixtyCycle().GetName(); got != c.name {
+			t.Errorf("%s 月柱期望 %s, 实际 %s", d, c.name, got)
+		}
+	}
+
+	// 时柱，23点起为次日子时
+	t1 := festival.NewSolarDayFromTime(time.Date(2000, 1, 1, 0, 30, 0, 0, time.Local))
+	if c := t1.GetHourSixtyCycle(); c.GetName() != "壬子" {
+		t.Errorf("戊日子时期望壬子, 实际 %s", c)
+	}
+	t2 := festival.NewSolarDayFromTime(time.Date(1999, 12, 31, 23, 30, 0, 0, time.Local))
+	if c := t2.GetHourSixtyCycle(); c.GetName() != "壬子" {
+		t.Errorf("前一日23点期望壬子, 实际 %s", c)
+	}
+
+	stem := festival.NewHeavenStem(6)
+	if stem.GetName() != "庚" || stem.GetElement().GetName() != "金" {
+		t.Errorf("期望庚金, 实际 %s%s", stem, stem.GetElement())
+	}
+	if b := festival.NewEarthBranch(0); b.GetElement().GetName() != "水" {
+		t.Errorf("期望子水, 实际 %s", b.GetElement())
+	}
+}
@@ -3,8 +3,7 @@ package festival
 import (
 	"fmt"
 	"math"
-	"regexp"
-	"strconv"
+	"slices"
 	"time"
 )
 
@@ -45,6 +44,12 @@ func (o JulianDay) Subtract(target JulianDay) float64 { return o.day - target.Ge
 
 // GetSolarDay 转换为公历日
 func (o JulianDay) GetSolarDay() SolarDay {
+	t := o.GetSolarTime()
+	return SolarDay{year: t.year, month: t.month, day: t.day, hour: t.hour, minute: t.minute, second: t.second}
+}
+
+// GetSolarTime 转换为公历时刻
+func (o JulianDay) GetSolarTime() SolarTime {
 	d := int(o.day + 0.5)
 	f := o.day + 0.5 - float64(d)
 	if d >= 2299161 {
@@ -79,7 +84,7 @@ func (o JulianDay) GetSolarDay() SolarDay {
 		hour++
 		minute -= 60
 	}
-	return SolarDay{year: y, month: m, day: d, hour: hour, minute: minute, second: second}
+	return SolarTime{year: y, month: m, day: d, hour: hour, minute: minute, second: second}
 }
 
 // ============ 公历日 ============
@@ -96,6 +101,9 @@ type SolarDay struct {
 
 // NewSolarDay 创建公历日
 func NewSolarDay(year, month, day int) (SolarDay, error) {
+	if year < MinYear || year > MaxYear {
+		return SolarDay{}, outOfRange(fmt.Sprintf("%d年", year))
+	}
 	if month < 1 || month > 12 {
 		return SolarDay{}, fmt.Errorf("非法月份: %d", month)
 	}
@@ -106,7 +114,8 @@ func NewSolarDay(year, month, day int) (SolarDay, error) {
 	return SolarDay{year: year, month: month, day: day}, nil
 }
 
-// NewSolarDayFromTime 从time.Time创建公历日
+// NewSolarDayFromTime 从time.Time创建公历日，取t所在时区（t.Location()）的日期和时刻
+// 需要按日历参考时区取日时，先转换时区：NewSolarDayFromTime(t.In(CalendarLocation()))
 func NewSolarDayFromTime(t time.Time) SolarDay {
 	return SolarDay{
 		year:   t.Year(),
@@ -118,9 +127,9 @@ func NewSolarDayFromTime(t time.Time) SolarDay {
 	}
 }
 
-// Today 获取今天
+// Today 获取日历参考时区（默认北京时间）的今天，与本机时区无关，按包默认时钟（见 SetClock）
 func Today() SolarDay {
-	return NewSolarDayFromTime(time.Now())
+	return TodayFrom(CurrentClock())
 }
 
 func (o SolarDay) GetYear() int  { return o.year }
@@ -160,21 +169,45 @@ func (o SolarDay) Equals(target SolarDay) bool {
 	return o.year == target.year && o.month == target.month && o.day == target.day
 }
 
-// Subtract 日期相减，获取天数差
+// Subtract 日期相减，获取天数差（只比较日期，忽略时分秒）
 func (o SolarDay) Subtract(target SolarDay) int {
-	return int(o.GetJulianDay().Subtract(target.GetJulianDay()))
+	return o.dayNumber() - target.dayNumber()
+}
+
+// DaysUntil 距离目标日期还有几天，只比较日期（忽略时分秒）：当天为0，明天为1，目标在之前时为负数
+// 倒计时一律按此计算，天数只在零点变化
+func (o SolarDay) DaysUntil(target SolarDay) int {
+	return target.dayNumber() - o.dayNumber()
 }
 
-// GetLunarDay 获取农历日
+// DurationUntil 从当前时刻（含时分秒）到目标日期零点的精确时长，目标已开始时为负数
+// 按日历参考时区的钟面时间计算，参考时区为固定时区，每天都是24小时
+func (o SolarDay) DurationUntil(target SolarDay) time.Duration {
+	elapsed := time.Duration(o.hour)*time.Hour + time.Duration(o.minute)*time.Minute + time.Duration(o.second)*time.Second
+	return time.Duration(o.DaysUntil(target))*24*time.Hour - elapsed
+}
+
+// GetLunarDay 获取农历日，超出支持范围时返回零值，需要区分时使用 LunarDayOf
 func (o SolarDay) GetLunarDay() LunarDay {
-	m, _ := NewLunarMonth(o.year, o.month)
+	d, err := LunarDayOf(o)
+	if err != nil {
+		return LunarDay{}
+	}
+	return d
+}
+
+// computeLunarDay 计算农历日（不使用年度索引）
+func (o SolarDay) computeLunarDay() (LunarDay, error) {
+	m, err := NewLunarMonth(o.year, o.month)
+	if err != nil {
+		return LunarDay{}, err
+	}
 	days := o.Subtract(m.GetFirstJulianDay().GetSolarDay())
 	for days < 0 {
 		m = m.Next(-1)
 		days += m.GetDayCount()
 	}
-	d, _ := NewLunarDay(m.GetYear(), m.GetMonthWithLeap(), days+1)
-	return d
+	return NewLunarDay(m.GetYear(), m.GetMonthWithLeap(), days+1)
 }
 
 // GetSolarFestival 获取公历节日
@@ -190,21 +223,24 @@ func (o SolarDay) GetLunarFestival() *LunarFestival {
 	return f
 }
 
-// GetSolarTerm 获取当天节气
+// GetNearestSolarTerm 获取从当天起（含当天）最近的节气
+func (o SolarDay) GetNearestSolarTerm() SolarTerm {
+	n := o.dayNumber()
+	term := NewSolarTermFromIndex(o.year, 0)
+	for term.GetSolarDay().dayNumber() < n {
+		term = term.Next(1)
+	}
+	return term
+}
+
+// GetSolarTerm 获取当天节气，超出支持范围时返回nil
 func (o SolarDay) GetSolarTerm() *SolarTerm {
-	y := o.year
-	for i := 0; i < 24; i++ {
-		term := NewSolarTermFromIndex(y, i)
-		if term.GetSolarDay().Equals(o) {
-			return &term
-		}
+	if !o.IsSupported() {
+		return nil
 	}
-	// 检查下一年的前几个节气
-	for i := 0; i < 2; i++ {
-		term := NewSolarTermFromIndex(y+1, i)
-		if term.GetSolarDay().Equals(o) {
-			return &term
-		}
+	idx := getYearIndex(o.year)
+	if term, ok := idx.terms[idx.lookup(o)]; ok {
+		return &term
 	}
 	return nil
 }
@@ -212,32 +248,37 @@ func (o SolarDay) GetSolarTerm() *SolarTerm {
 // ============ 公历节日 ============
 
 // SolarFestivalNames 公历节日名称
-var SolarFestivalNames = []string{"元旦", "三八妇女节", "植树节", "五一劳动节", "五四青年节", "六一儿童节", "建党节", "八一建军节", "教师节", "国庆节"}
-
-// SolarFestivalData 公历节日数据 格式: @索引类型月日起始年
-var SolarFestivalData = "@00001011950@01003081950@02003121979@03005011950@04005041950@05006011950@06007011941@07008011933@08009101985@09010011950"
+//
+// Deprecated: 节日定义已改为数据集，请使用 DefaultDataset().GetDefinitions()。此变量由内置数据集生成，修改它不影响节日查询
+var SolarFestivalNames = defaultDataset.legacySolarNames()
+
+// SolarFestivalData 公历节日数据 格式: @索引类型规则起始年[结束年]
+// 类型0 固定日期，规则为月日，如@00001011950为1950年起的1月1日
+// 类型1 第N个星期几，规则为月、第几个、星期几(0为星期日)，如@10105201914为1914年起5月的第2个星期日
+// 类型2 最后一个星期几，规则为月、星期几
+//
+// Deprecated: 同 SolarFestivalNames
+var SolarFestivalData = defaultDataset.legacySolarData()
 
 // SolarFestival 公历节日
 type SolarFestival struct {
-	name string
+	name         string
+	festivalType FestivalTypeEnum
+	tags         []string
 }
 
-// GetSolarFestivalByYmd 根据年月日获取公历节日
+// GetSolarFestivalByYmd 根据年月日获取公历节日，节日定义来自当前数据集，同一天有多个时返回第一个
 func GetSolarFestivalByYmd(year int, month int, day int) (*SolarFestival, error) {
-	re, err := regexp.Compile(fmt.Sprintf("@\\d{2}0%02d%02d\\d+", month, day))
+	d, err := NewSolarDay(year, month, day)
 	if err != nil {
 		return nil, err
 	}
-	data := re.FindString(SolarFestivalData)
-	if data == "" {
-		return nil, nil
-	}
-	startYear, _ := strconv.Atoi(data[8:])
-	if year < startYear {
-		return nil, nil
+	dataset := CurrentDataset()
+	if k := dataset.solarFestivals(d); len(k) > 0 {
+		def := dataset.solar[k[0]]
+		return &SolarFestival{name: def.Name, festivalType: def.festivalType, tags: def.Tags}, nil
 	}
-	index, _ := strconv.Atoi(data[1:3])
-	return &SolarFestival{name: SolarFestivalNames[index]}, nil
+	return nil, nil
 }
 
 // GetName 获取名称
@@ -245,6 +286,11 @@ func (o SolarFestival) GetName() string {
 	return o.name
 }
 
+// GetTags 获取标签
+func (o SolarFestival) GetTags() []string {
+	return o.tags
+}
+
 // ============ 节气 ============
 
 // SolarTermNames 节气名称（从冬至开始）
@@ -252,6 +298,8 @@ var SolarTermNames = []string{"冬至", "小寒", "大寒", "立春", "雨水",
 
 // SolarTerm 节气
 type SolarTerm struct {
+	year             int
+	index            int
 	name             string
 	cursoryJulianDay float64
 }
@@ -268,6 +316,8 @@ func NewSolarTermFromIndex(year int, index int) SolarTerm {
 		y--
 	}
 	return SolarTerm{
+		year:             y,
+		index:            idx,
 		name:             SolarTermNames[idx],
 		cursoryJulianDay: initTermByYear(y, idx),
 	}
@@ -278,19 +328,49 @@ func (o SolarTerm) GetName() string {
 	return o.name
 }
 
+// GetIndex 获取索引，0为冬至
+func (o SolarTerm) GetIndex() int {
+	return o.index
+}
+
+// Next 推移n个节气
+func (o SolarTerm) Next(n int) SolarTerm {
+	return NewSolarTermFromIndex(o.year, o.index+n)
+}
+
 // GetSolarDay 获取公历日
 func (o SolarTerm) GetSolarDay() SolarDay {
-	return NewJulianDay(o.cursoryJulianDay + J2000).GetSolarDay()
+	return o.GetCursoryJulianDay().GetSolarDay()
+}
+
+// GetCursoryJulianDay 获取节气当天的儒略日（只精确到日）
+func (o SolarTerm) GetCursoryJulianDay() JulianDay {
+	return NewJulianDay(o.cursoryJulianDay + J2000)
+}
+
+// GetJulianDay 获取节气交节时刻的儒略日（日历参考时区）
+func (o SolarTerm) GetJulianDay() JulianDay {
+	return NewJulianDay(QiAccurate2(o.cursoryJulianDay) + J2000 + calendarShift())
+}
+
+// GetSolarTime 获取节气交节时刻（日历参考时区）
+func (o SolarTerm) GetSolarTime() SolarTime {
+	return o.GetJulianDay().GetSolarTime()
+}
+
+// GetTime 获取节气交节时刻，并转换到指定时区
+func (o SolarTerm) GetTime(loc *time.Location) time.Time {
+	return o.GetJulianDay().GetTime(loc)
 }
 
 // initTermByYear 根据年份和节气索引初始化节气儒略日
 func initTermByYear(year int, offset int) float64 {
 	jd := math.Floor(float64(year-2000)*365.2422 + 180)
 	w := math.Floor((jd-355+183)/365.2422)*365.2422 + 355
-	if CalcQi(w) > jd {
+	if calcQi(w) > jd {
 		w -= 365.2422
 	}
-	return CalcQi(w + 15.2184*float64(offset))
+	return calcQi(w + 15.2184*float64(offset))
 }
 
 // ============ 节日统一结构 ============
@@ -305,6 +385,10 @@ const (
 	FestivalTypeLunar
 	// FestivalTypeSolarTerm 节气
 	FestivalTypeSolarTerm
+	// FestivalTypeSeason 时令（数九、三伏）
+	FestivalTypeSeason
+	// FestivalTypePersonal 个人纪念日（生日、周年纪念日）
+	FestivalTypePersonal
 )
 
 // String 获取节日类型名称
@@ -316,6 +400,10 @@ func (t FestivalTypeEnum) String() string {
 		return "农历节日"
 	case FestivalTypeSolarTerm:
 		return "节气"
+	case FestivalTypeSeason:
+		return "时令"
+	case FestivalTypePersonal:
+		return "个人纪念日"
 	default:
 		return "未知"
 	}
@@ -326,6 +414,10 @@ type Festival struct {
 	Type     FestivalTypeEnum
 	Name     string
 	SolarDay SolarDay
+	// Tags 标签，来自节日数据集，如“法定”
+	Tags []string
+	// Holiday 法定节日所在的放假安排（需使用WithHolidayCalendar），不放假时为nil
+	Holiday *Holiday
 }
 
 // String 字符串表示
@@ -333,31 +425,146 @@ func (f Festival) String() string {
 	return fmt.Sprintf("%s %s (%s)", f.SolarDay.String(), f.Name, f.Type.String())
 }
 
-// GetNearestFestival 获取最近的节日（向后查找）
+// FestivalOption 节日查询选项
+type FestivalOption func(*festivalOptions)
+
+// festivalOptions 节日查询选项集合
+type festivalOptions struct {
+	seasons  bool
+	personal []PersonalEvent
+	holidays *HolidayCalendar
+	types    map[FestivalTypeEnum]bool
+	names    map[string]bool
+	filters  []func(Festival) bool
+}
+
+// WithPersonalEvents 包含个人纪念日（生日、周年纪念日），可多次使用
+func WithPersonalEvents(events ...PersonalEvent) FestivalOption {
+	return func(o *festivalOptions) {
+		o.personal = append(o.personal, events...)
+	}
+}
+
+// WithHolidayCalendar 按放假安排为法定节日（标签为“法定”）附上放假区间，见 Festival.Holiday
+func WithHolidayCalendar(c *HolidayCalendar) FestivalOption {
+	return func(o *festivalOptions) {
+		o.holidays = c
+	}
+}
+
+// WithSeasons 包含内置的数九、三伏时令（每一九、每一伏的第一天）
+func WithSeasons() FestivalOption {
+	return func(o *festivalOptions) {
+		o.seasons = true
+	}
+}
+
+// WithTypes 只保留指定类型的节日，指定FestivalTypeSeason时自动包含内置时令
+func WithTypes(types ...FestivalTypeEnum) FestivalOption {
+	return func(o *festivalOptions) {
+		if o.types == nil {
+			o.types = map[FestivalTypeEnum]bool{}
+		}
+		for _, t := range types {
+			o.types[t] = true
+		}
+	}
+}
+
+// WithNames 只保留指定名称的节日
+func WithNames(names ...string) FestivalOption {
+	return func(o *festivalOptions) {
+		if o.names == nil {
+			o.names = map[string]bool{}
+		}
+		for _, n := range names {
+			o.names[n] = true
+		}
+	}
+}
+
+// WithFilter 只保留满足条件的节日，可多次指定，需全部满足
+func WithFilter(filter func(Festival) bool) FestivalOption {
+	return func(o *festivalOptions) {
+		o.filters = append(o.filters, filter)
+	}
+}
+
+// newFestivalOptions 合并节日查询选项
+func newFestivalOptions(opts []FestivalOption) festivalOptions {
+	var o festivalOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.types[FestivalTypeSeason] {
+		o.seasons = true
+	}
+	return o
+}
+
+// accept 节日是否满足过滤条件
+func (o festivalOptions) accept(f Festival) bool {
+	if o.types != nil && !o.types[f.Type] {
+		return false
+	}
+	if o.names != nil && !o.names[f.Name] {
+		return false
+	}
+	for _, filter := range o.filters {
+		if !filter(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// GetNearestFestival 获取最近的节日（向后查找），同一天有多个节日时返回优先级最高的一个
 // maxDays: 最大查找天数
-func (o SolarDay) GetNearestFestival(maxDays int) *Festival {
+func (o SolarDay) GetNearestFestival(maxDays int, opts ...FestivalOption) *Festival {
+	if festivals := o.GetNearestFestivals(maxDays, opts...); len(festivals) > 0 {
+		return &festivals[0]
+	}
+	return nil
+}
+
+// GetNearestFestivals 获取最近有节日的那一天的全部节日（向后查找），顺序同GetFestivals
+// maxDays: 最大查找天数
+func (o SolarDay) GetNearestFestivals(maxDays int, opts ...FestivalOption) []Festival {
 	for i := 0; i <= maxDays; i++ {
-		if f := o.Next(i).checkFestival(); f != nil {
-			return f
+		if festivals := o.Next(i).GetFestivals(opts...); len(festivals) > 0 {
+			return festivals
 		}
 	}
 	return nil
 }
 
-// checkFestival 检查当天是否有节日
-func (o SolarDay) checkFestival() *Festival {
-
-	// 检查公历节日
-	if sf := o.GetSolarFestival(); sf != nil {
-		return &Festival{Type: FestivalTypeSolar, Name: sf.GetName(), SolarDay: o}
+// GetFestivals 获取当天的全部节日，超出支持范围时返回nil
+// 优先级: 个人纪念日 > 农历节日 > 公历节日 > 节气 > 时令，如中秋节与国庆节同日时中秋节在前，清明节在节气清明之前
+func (o SolarDay) GetFestivals(opts ...FestivalOption) []Festival {
+	if !o.IsSupported() {
+		return nil
 	}
-	// 检查农历节日
-	if lf := o.GetLunarFestival(); lf != nil {
-		return &Festival{Type: FestivalTypeLunar, Name: lf.GetName(), SolarDay: o}
+	options := newFestivalOptions(opts)
+	festivals := o.getPersonalFestivals(options.personal)
+	idx := getYearIndex(o.year)
+	i := idx.lookup(o)
+	for _, f := range idx.festivals[i] {
+		f.SolarDay = o
+		festivals = append(festivals, f)
 	}
-	// 检查节气
-	if term := o.GetSolarTerm(); term != nil {
-		return &Festival{Type: FestivalTypeSolarTerm, Name: term.GetName(), SolarDay: o}
+	// 只有内置时令受WithSeasons控制，数据集中type为season的节日照常返回
+	if name, ok := idx.seasons[i]; ok && options.seasons {
+		festivals = append(festivals, Festival{Type: FestivalTypeSeason, Name: name, SolarDay: o})
 	}
-	return nil
+	result := festivals[:0]
+	for _, f := range festivals {
+		if !options.accept(f) {
+			continue
+		}
+		if options.holidays != nil && slices.Contains(f.Tags, TagStatutory) {
+			f.Holiday = options.holidays.GetHoliday(o)
+		}
+		result = append(result, f)
+	}
+	return result
 }
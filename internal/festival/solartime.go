@@ -0,0 +1,68 @@
+package festival
+
+import (
+	"fmt"
+	"time"
+)
+
+// ============ 公历时刻 ============
+
+// SolarTime 公历时刻
+type SolarTime struct {
+	year   int
+	month  int
+	day    int
+	hour   int
+	minute int
+	second int
+}
+
+// NewSolarTime 创建公历时刻
+func NewSolarTime(year, month, day, hour, minute, second int) (SolarTime, error) {
+	if _, err := NewSolarDay(year, month, day); err != nil {
+		return SolarTime{}, err
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 || second < 0 || second > 59 {
+		return SolarTime{}, fmt.Errorf("非法时刻: %02d:%02d:%02d", hour, minute, second)
+	}
+	return SolarTime{year: year, month: month, day: day, hour: hour, minute: minute, second: second}, nil
+}
+
+// NewSolarTimeFromTime 从time.Time创建公历时刻，取t所在时区（t.Location()）的钟面时间
+func NewSolarTimeFromTime(t time.Time) SolarTime {
+	return SolarTime{
+		year:   t.Year(),
+		month:  int(t.Month()),
+		day:    t.Day(),
+		hour:   t.Hour(),
+		minute: t.Minute(),
+		second: t.Second(),
+	}
+}
+
+func (o SolarTime) GetYear() int   { return o.year }
+func (o SolarTime) GetMonth() int  { return o.month }
+func (o SolarTime) GetDay() int    { return o.day }
+func (o SolarTime) GetHour() int   { return o.hour }
+func (o SolarTime) GetMinute() int { return o.minute }
+func (o SolarTime) GetSecond() int { return o.second }
+
+// GetSolarDay 获取公历日（不含时分秒）
+func (o SolarTime) GetSolarDay() SolarDay {
+	return SolarDay{year: o.year, month: o.month, day: o.day}
+}
+
+// GetJulianDay 获取儒略日
+func (o SolarTime) GetJulianDay() JulianDay {
+	return JulianDayFromYmdHms(o.year, o.month, o.day, o.hour, o.minute, o.second)
+}
+
+// GetTime 转换为time.Time（按日历参考时区的钟面时间解释），并转换到指定时区
+func (o SolarTime) GetTime(loc *time.Location) time.Time {
+	return time.Date(o.year, time.Month(o.month), o.day, o.hour, o.minute, o.second, 0, CalendarLocation()).In(loc)
+}
+
+// String 字符串表示
+func (o SolarTime) String() string {
+	return fmt.Sprintf("%s %02d:%02d:%02d", o.GetSolarDay(), o.hour, o.minute, o.second)
+}
@@ -0,0 +1,64 @@
+package festival
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ============ 支持范围 ============
+
+// MinYear、MaxYear 支持的公历年份范围（含），农历日期、节气、节日只在该范围内计算
+// 1582年10月15日以前按儒略历；农历以寿星天文历计算，越古老、越遥远的年份与实际历书的误差越大
+const (
+	MinYear = 1
+	MaxYear = 9999
+)
+
+var (
+	// ErrOutOfRange 日期超出支持范围
+	ErrOutOfRange = errors.New("超出支持范围")
+	// ErrInvalidLeapMonth 该农历年没有这个闰月
+	ErrInvalidLeapMonth = errors.New("闰月不存在")
+)
+
+// 支持范围首尾两天的儒略日（正午）
+var (
+	minDayNumber = SolarDay{year: MinYear, month: 1, day: 1}.dayNumber()
+	maxDayNumber = SolarDay{year: MaxYear, month: 12, day: 31}.dayNumber()
+)
+
+// outOfRange 生成超出支持范围的错误，如“10000-01-01: 超出支持范围（公历1-9999年）”
+func outOfRange(what string) error {
+	return fmt.Errorf("%s: %w（公历%d-%d年）", what, ErrOutOfRange, MinYear, MaxYear)
+}
+
+// IsSupported 是否在支持范围内
+func (o SolarDay) IsSupported() bool {
+	return o.year >= MinYear && o.year <= MaxYear
+}
+
+// LunarDayOf 获取公历日对应的农历日，超出支持范围时返回ErrOutOfRange
+func LunarDayOf(d SolarDay) (LunarDay, error) {
+	if !d.IsSupported() {
+		return LunarDay{}, outOfRange(d.String())
+	}
+	idx := getYearIndex(d.year)
+	l := idx.lunar[idx.lookup(d)]
+	return NewLunarDay(l.year, l.month, l.day)
+}
+
+// SolarTermOf 获取公历日当天的节气，不是节气返回nil，超出支持范围时返回ErrOutOfRange
+func SolarTermOf(d SolarDay) (*SolarTerm, error) {
+	if !d.IsSupported() {
+		return nil, outOfRange(d.String())
+	}
+	return d.GetSolarTerm(), nil
+}
+
+// FestivalsOf 获取公历日当天的全部节日，超出支持范围时返回ErrOutOfRange
+func FestivalsOf(d SolarDay, opts ...FestivalOption) ([]Festival, error) {
+	if !d.IsSupported() {
+		return nil, outOfRange(d.String())
+	}
+	return d.GetFestivals(opts...), nil
+}
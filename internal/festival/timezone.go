@@ -0,0 +1,87 @@
+package festival
+
+import (
+	"math"
+	"sync/atomic"
+	"time"
+)
+
+// ============ 日历参考时区 ============
+
+// DefaultCalendarLocation 默认日历参考时区：东经120°标准时（北京时间，UTC+8）
+// 寿星天文历的节气、朔日均按北京时间计算，农历取日也以此为准
+var DefaultCalendarLocation = time.FixedZone("CST", 8*3600)
+
+// calendarZone 日历参考时区
+type calendarZone struct {
+	loc *time.Location
+	// shift 相对北京时间的偏移（天），用于把节气、朔的时刻换算到参考时区再取日
+	shift float64
+}
+
+var currentZone atomic.Pointer[calendarZone]
+
+func init() {
+	currentZone.Store(&calendarZone{loc: DefaultCalendarLocation})
+}
+
+// CalendarLocation 获取日历参考时区
+func CalendarLocation() *time.Location {
+	return currentZone.Load().loc
+}
+
+// SetCalendarLocation 设置日历参考时区（参考子午线），传nil恢复为北京时间
+// 节气、朔日按该时区取日，农历月的大小、节气所在日期可能随之变化，如越南农历使用UTC+7
+// 参考时区应为固定时区（time.FixedZone），有夏令时的时区按2000年1月1日的偏移计算
+// 参考时区不是北京时间时，1960年以前的节气、朔日使用天文算法而不是历史历表，闰月仍按内置闰月表
+func SetCalendarLocation(loc *time.Location) {
+	if loc == nil {
+		loc = DefaultCalendarLocation
+	}
+	_, offset := time.Date(2000, 1, 1, 0, 0, 0, 0, loc).Zone()
+	currentZone.Store(&calendarZone{loc: loc, shift: float64(offset-8*3600) / SecondPerDay})
+	lunarMonthCache.Clear()
+	resetYearIndexes()
+}
+
+// calendarShift 获取参考时区相对北京时间的偏移（天）
+func calendarShift() float64 {
+	return currentZone.Load().shift
+}
+
+// TodayIn 获取指定时区的今天，按包默认时钟（见 SetClock）
+func TodayIn(loc *time.Location) SolarDay {
+	return NewSolarDayFromTime(CurrentClock().Now().In(loc))
+}
+
+// JulianDayFromTime 从time.Time创建儒略日，按日历参考时区的钟面时间计算
+func JulianDayFromTime(t time.Time) JulianDay {
+	t = t.In(CalendarLocation())
+	jd := JulianDayFromYmdHms(t.Year(), int(t.Month()), t.Day(), t.Hour(), t.Minute(), t.Second())
+	return NewJulianDay(jd.day + float64(t.Nanosecond())/1e9/SecondPerDay)
+}
+
+// GetTime 转换为time.Time（儒略日按日历参考时区的钟面时间解释），并转换到指定时区
+func (o JulianDay) GetTime(loc *time.Location) time.Time {
+	return o.GetSolarTime().GetTime(loc)
+}
+
+// calcQi 计算节气所在日，按日历参考时区取日
+func calcQi(jd float64) float64 {
+	shift := calendarShift()
+	if shift == 0 {
+		return CalcQi(jd)
+	}
+	w := math.Floor((jd+293)/365.2422*24) * math.Pi / 12
+	return math.Floor(qiHigh(w) + shift + 0.5)
+}
+
+// calcShuo 计算朔日，按日历参考时区取日
+func calcShuo(jd float64) float64 {
+	shift := calendarShift()
+	if shift == 0 {
+		return CalcShuo(jd)
+	}
+	w := math.Floor((jd+8)/29.5306) * Pi2
+	return math.Floor(shuoHigh(w) + shift + 0.5)
+}
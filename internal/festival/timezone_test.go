@@ -0,0 +1,73 @@
+package festival_test
+
+import (
+	"testing"
+	"time"
+
+	"workoff-timer/internal/festival"
+)
+
+// TestCalendarLocation 时区与日历参考时区测试
+func TestCalendarLocation(t *testing.T) {
+	// 同一时刻在UTC是1月28日，在北京时间已是1月29日（春节）
+	instant := time.Date(2025, 1, 28, 16, 30, 0, 0, time.UTC)
+	if d := festival.NewSolarDayFromTime(instant); d.String() != "2025年1月28日" {
+		t.Errorf("UTC期望2025年1月28日, 实际 %s", d)
+	}
+	d := festival.NewSolarDayFromTime(instant.In(festival.CalendarLocation()))
+	if d.String() != "2025年1月29日" || d.GetLunarDay().String() != "农历二〇二五年正月初一" {
+		t.Errorf("北京时间期望2025年1月29日正月初一, 实际 %s %s", d, d.GetLunarDay())
+	}
+
+	jd := festival.JulianDayFromTime(instant)
+	if s := jd.GetSolarTime().String(); s != "2025年1月29日 00:30:00" {
+		t.Errorf("期望2025年1月29日 00:30:00, 实际 %s", s)
+	}
+	if back := jd.GetTime(time.UTC); !back.Equal(instant) {
+		t.Errorf("期望 %s, 实际 %s", instant, back)
+	}
+
+	// 交节时刻与时区无关
+	term := festival.NewSolarTermFromIndex(2025, 3)
+	if a, b := term.GetTime(time.UTC), term.GetTime(festival.CalendarLocation()); !a.Equal(b) {
+		t.Errorf("交节时刻不一致: %s %s", a, b)
+	}
+
+	// 2030年正月初一的朔在北京时间2月3日凌晨，按UTC+7取日为2月2日
+	festival.SetCalendarLocation(time.FixedZone("ICT", 7*3600))
+	defer festival.SetCalendarLocation(nil)
+	m, _ := festival.NewLunarMonth(2030, 1)
+	if s := m.GetFirstJulianDay().GetSolarDay().String(); s != "2030年2月2日" {
+		t.Errorf("UTC+7期望2030年2月2日, 实际 %s", s)
+	}
+	if a, b := festival.NewSolarTermFromIndex(2025, 3).GetTime(time.UTC), term.GetTime(time.UTC); a.Sub(b).Abs() > time.Second {
+		t.Errorf("交节时刻不应随参考时区变化: %s %s", a, b)
+	}
+	festival.SetCalendarLocation(nil)
+	m, _ = festival.NewLunarMonth(2030, 1)
+	if s := m.GetFirstJulianDay().GetSolarDay().String(); s != "2030年2月3日" {
+		t.Errorf("北京时间期望2030年2月3日, 实际 %s", s)
+	}
+}
+
+// TestClock 时钟测试
+func TestClock(t *testing.T) {
+	at := time.Date(2026, 2, 16, 18, 59, 0, 0, festival.CalendarLocation())
+	festival.SetClock(festival.NewFixedClock(at))
+	defer festival.SetClock(nil)
+	if d := festival.Today(); d.String() != "2026年2月16日" || d.GetHourSixtyCycle().GetEarthBranch().GetName() != "酉" {
+		t.Errorf("期望2026年2月16日酉时, 实际 %s", d)
+	}
+	if d := festival.TodayIn(time.UTC); d.String() != "2026年2月16日" {
+		t.Errorf("期望2026年2月16日, 实际 %s", d)
+	}
+
+	base := festival.NewFixedClock(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	c := festival.NewOffsetClockAt(base, at)
+	if !c.Now().Equal(at) {
+		t.Errorf("期望 %s, 实际 %s", at, c.Now())
+	}
+	if d := festival.TodayFrom(festival.NewOffsetClock(c, 24*time.Hour)); d.String() != "2026年2月17日" {
+		t.Errorf("期望2026年2月17日, 实际 %s", d)
+	}
+}
@@ -0,0 +1,88 @@
+package festival
+
+import "time"
+
+// ============ 星期 ============
+
+// WeekNames 星期名称，0为星期日，与time.Weekday一致
+var WeekNames = []string{"日", "一", "二", "三", "四", "五", "六"}
+
+// Week 星期
+type Week struct {
+	index int
+}
+
+// NewWeek 根据索引创建星期，0为星期日
+func NewWeek(index int) Week {
+	return Week{index: cycleIndex(index, len(WeekNames))}
+}
+
+func (o Week) GetIndex() int            { return o.index }
+func (o Week) GetName() string          { return WeekNames[o.index] }
+func (o Week) GetWeekday() time.Weekday { return time.Weekday(o.index) }
+func (o Week) Next(n int) Week          { return NewWeek(o.index + n) }
+func (o Week) Equals(target Week) bool  { return o.index == target.index }
+func (o Week) IsWeekend() bool          { return o.index == 0 || o.index == 6 }
+func (o Week) String() string           { return "星期" + o.GetName() }
+
+// GetWeek 获取星期
+func (o SolarDay) GetWeek() Week {
+	return NewWeek(o.dayNumber() + 1)
+}
+
+// GetISOWeek 获取ISO-8601周数及其所属年份，每周从星期一开始，包含当年第一个星期四的周为第1周
+func (o SolarDay) GetISOWeek() (year, week int) {
+	return time.Date(o.year, time.Month(o.month), o.day, 0, 0, 0, 0, time.UTC).ISOWeek()
+}
+
+// GetWeekOfMonth 获取当月第几周（从1开始），start为每周的第一天，月初不足一周的部分算作第1周
+func (o SolarDay) GetWeekOfMonth(start Week) int {
+	first := SolarDay{year: o.year, month: o.month, day: 1}
+	offset := cycleIndex(first.GetWeek().index-start.index, 7)
+	return (o.day-1+offset)/7 + 1
+}
+
+// GetSolarWeek 获取所在的公历周，start为每周的第一天
+func (o SolarDay) GetSolarWeek(start Week) SolarWeek {
+	offset := cycleIndex(o.GetWeek().index-start.index, 7)
+	return SolarWeek{first: SolarDay{year: o.year, month: o.month, day: o.day}.Next(-offset), start: start}
+}
+
+// ============ 公历周 ============
+
+// SolarWeek 公历周
+type SolarWeek struct {
+	first SolarDay
+	start Week
+}
+
+// GetStart 获取每周的第一天是星期几
+func (o SolarWeek) GetStart() Week { return o.start }
+
+// GetFirstDay 获取本周第一天
+func (o SolarWeek) GetFirstDay() SolarDay { return o.first }
+
+// GetDays 获取本周的7天
+func (o SolarWeek) GetDays() []SolarDay {
+	days := make([]SolarDay, 0, 7)
+	for i := 0; i < 7; i++ {
+		days = append(days, o.first.Next(i))
+	}
+	return days
+}
+
+// Contains 是否包含指定日期
+func (o SolarWeek) Contains(d SolarDay) bool {
+	n := d.dayNumber() - o.first.dayNumber()
+	return n >= 0 && n < 7
+}
+
+// Next 推移n周
+func (o SolarWeek) Next(n int) SolarWeek {
+	return SolarWeek{first: o.first.Next(7 * n), start: o.start}
+}
+
+// String 字符串表示
+func (o SolarWeek) String() string {
+	return o.first.String() + "起的一周"
+}
@@ -0,0 +1,53 @@
+package festival_test
+
+import (
+	"testing"
+
+	"workoff-timer/internal/festival"
+)
+
+// TestWeek 星期与周数测试
+func TestWeek(t *testing.T) {
+	day, _ := festival.NewSolarDay(2025, 10, 17)
+	if w := day.GetWeek(); w.String() != "星期五" {
+		t.Errorf("2025年10月17日期望星期五, 实际 %s", w)
+	}
+
+	// 2024年12月30日属于2025年第1周，2021年1月3日属于2020年第53周
+	cases := []struct {
+		y, m, d    int
+		year, week int
+	}{
+		{2024, 12, 30, 2025, 1},
+		{2021, 1, 3, 2020, 53},
+		{2025, 10, 17, 2025, 42},
+	}
+	for _, c := range cases {
+		d, _ := festival.NewSolarDay(c.y, c.m, c.d)
+		if year, week := d.GetISOWeek(); year != c.year || week != c.week {
+			t.Errorf("%s 期望%d年第%d周, 实际%d年第%d周", d, c.year, c.week, year, week)
+		}
+	}
+
+	// 2025年10月1日是星期三：周一起算时10月6日为第2周，周日起算时10月5日为第2周
+	d6, _ := festival.NewSolarDay(2025, 10, 6)
+	d5, _ := festival.NewSolarDay(2025, 10, 5)
+	if n := d6.GetWeekOfMonth(festival.NewWeek(1)); n != 2 {
+		t.Errorf("期望第2周, 实际第%d周", n)
+	}
+	if n := d5.GetWeekOfMonth(festival.NewWeek(1)); n != 1 {
+		t.Errorf("期望第1周, 实际第%d周", n)
+	}
+	if n := d5.GetWeekOfMonth(festival.NewWeek(0)); n != 2 {
+		t.Errorf("期望第2周, 实际第%d周", n)
+	}
+
+	week := day.GetSolarWeek(festival.NewWeek(1))
+	days := week.GetDays()
+	if len(days) != 7 || days[0].String() != "2025年10月13日" || days[6].String() != "2025年10月19日" {
+		t.Errorf("期望2025年10月13日至19日, 实际 %v", days)
+	}
+	if !week.Contains(day) || week.Next(1).Contains(day) {
+		t.Errorf("周包含关系错误")
+	}
+}
@@ -0,0 +1,192 @@
+package overtime
+
+import (
+	"fmt"
+	"time"
+
+	"workoff-timer/internal/schedule"
+)
+
+// ============ 加班类别 ============
+
+// Category 加班类别，决定加班工资的倍数
+type Category int
+
+const (
+	CategoryWorkday Category = iota // 工作日延长工作时间，150%
+	CategoryRestDay                 // 休息日工作，200%
+	CategoryHoliday                 // 法定假日工作，300%
+)
+
+// CategoryNames 加班类别名称
+var CategoryNames = []string{"工作日", "休息日", "法定假日"}
+
+// Multipliers 各类别加班工资相对小时工资的倍数（《劳动法》第四十四条）
+var Multipliers = []float64{1.5, 2, 3}
+
+// String 获取名称
+func (c Category) String() string {
+	return CategoryNames[c]
+}
+
+// GetMultiplier 获取加班工资倍数
+func (c Category) GetMultiplier() float64 {
+	return Multipliers[c]
+}
+
+// ============ 小时工资 ============
+
+// MonthlyWorkDays 月计薪天数：(365天 - 104天休息日) ÷ 12月
+const MonthlyWorkDays = 21.75
+
+// HourlyRate 按月工资计算小时工资：月工资 ÷ 21.75 ÷ 8
+func HourlyRate(monthlySalary float64) float64 {
+	return monthlySalary / MonthlyWorkDays / 8
+}
+
+// ============ 加班计算 ============
+
+// Holidays 判断某天是否为法定假日，day为当天零点
+type Holidays func(day time.Time) bool
+
+// Span 一段实际工作时间，如一次上班打卡到下班打卡
+type Span struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Engine 加班计算：按作息判断哪些工作时间是正常上班，其余为加班
+// 作息中有工作时段的日子，时段之外的工作为工作日加班；没有工作时段的日子为休息日加班；法定假日全天为法定假日加班
+type Engine struct {
+	schedule   *schedule.Schedule
+	holidays   Holidays
+	hourlyRate float64
+}
+
+// NewEngine 创建加班计算，monthlySalary为月工资（计算加班工资的基数）
+func NewEngine(s *schedule.Schedule, holidays Holidays, monthlySalary float64) (*Engine, error) {
+	if monthlySalary < 0 {
+		return nil, fmt.Errorf("非法月工资: %v", monthlySalary)
+	}
+	return &Engine{schedule: s, holidays: holidays, hourlyRate: HourlyRate(monthlySalary)}, nil
+}
+
+// GetHourlyRate 获取小时工资
+func (e *Engine) GetHourlyRate() float64 { return e.hourlyRate }
+
+// Compute 计算一段或多段工作时间的加班，跨零点的按日期拆开，各自按当天的类别计算
+func (e *Engine) Compute(spans ...Span) Report {
+	r := Report{hourlyRate: e.hourlyRate}
+	for _, span := range spans {
+		for start := span.Start; start.Before(span.End); {
+			day := startOfDay(start)
+			end := day.AddDate(0, 0, 1)
+			if span.End.Before(end) {
+				end = span.End
+			}
+			e.computeDay(&r, day, start, end)
+			start = end
+		}
+	}
+	return r
+}
+
+// computeDay 计算同一天内[start, end)的加班
+func (e *Engine) computeDay(r *Report, day time.Time, start time.Time, end time.Time) {
+	if e.holidays != nil && e.holidays(day) {
+		r.durations[CategoryHoliday] += end.Sub(start)
+		return
+	}
+	// 前一天跨零点的夜班和当天的班次之内为正常上班；当天有班次或接着前一天的夜班时，其余时间为工作日加班
+	// 加班中不计薪的加班休息（如晚饭）不算加班
+	category := CategoryRestDay
+	regular := time.Duration(0)
+	if w := e.schedule.WindowOn(day); w != nil {
+		category = CategoryWorkday
+		from, to := w.On(day)
+		regular += overlap(start, end, from, to) + unpaidOvertimeBreaks(*w, day, start, end)
+	}
+	yesterday := day.AddDate(0, 0, -1)
+	if w := e.schedule.WindowOn(yesterday); w != nil && w.IsOvernight() {
+		from, to := w.On(yesterday)
+		if d := overlap(start, end, from, to); d > 0 {
+			category, regular = CategoryWorkday, regular+d
+		}
+		regular += unpaidOvertimeBreaks(*w, yesterday, start, end)
+	}
+	r.durations[category] += end.Sub(start) - regular
+}
+
+// unpaidOvertimeBreaks 获取[start, end)落在时段w不计薪的加班休息中的时长，day为w的上班那天
+func unpaidOvertimeBreaks(w schedule.Window, day time.Time, start time.Time, end time.Time) time.Duration {
+	var d time.Duration
+	for _, b := range w.GetOvertimeBreaks() {
+		if !b.IsPaid() {
+			from, to := b.On(day)
+			d += overlap(start, end, from, to)
+		}
+	}
+	return d
+}
+
+// overlap 获取[start, end)与[from, to)重叠的时长
+func overlap(start time.Time, end time.Time, from time.Time, to time.Time) time.Duration {
+	if from.After(start) {
+		start = from
+	}
+	if to.Before(end) {
+		end = to
+	}
+	return max(0, end.Sub(start))
+}
+
+// startOfDay 获取t所在时区当天零点
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// ============ 加班汇总 ============
+
+// Report 加班汇总：各类别的加班时长和加班工资
+type Report struct {
+	hourlyRate float64
+	durations  [3]time.Duration
+}
+
+// GetDuration 获取某类别的加班时长
+func (o Report) GetDuration(c Category) time.Duration { return o.durations[c] }
+
+// GetPay 获取某类别的加班工资：小时工资 × 倍数 × 加班小时数
+func (o Report) GetPay(c Category) float64 {
+	return o.hourlyRate * c.GetMultiplier() * o.durations[c].Hours()
+}
+
+// GetTotalDuration 获取加班总时长
+func (o Report) GetTotalDuration() time.Duration {
+	var d time.Duration
+	for _, v := range o.durations {
+		d += v
+	}
+	return d
+}
+
+// GetTotalPay 获取加班工资合计
+func (o Report) GetTotalPay() float64 {
+	var pay float64
+	for c := range o.durations {
+		pay += o.GetPay(Category(c))
+	}
+	return pay
+}
+
+// String 字符串表示，如“工作日 2h30m0s 休息日 8h0m0s 法定假日 0s”
+func (o Report) String() string {
+	s := ""
+	for c, d := range o.durations {
+		if c > 0 {
+			s += " "
+		}
+		s += Category(c).String() + " " + d.String()
+	}
+	return s
+}
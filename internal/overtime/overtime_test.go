@@ -0,0 +1,93 @@
+package overtime_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"workoff-timer/internal/overtime"
+	"workoff-timer/internal/schedule"
+)
+
+var loc = time.FixedZone("CST", 8*3600)
+
+// TestCompute 工作日、休息日、法定假日加班测试
+func TestCompute(t *testing.T) {
+	// 周一至周五 09:00-18:00，10月1日法定假日，10月2日休息日
+	w, _ := schedule.NewWindow("09:00", "18:00")
+	holiday := time.Date(2026, 10, 1, 0, 0, 0, 0, loc)
+	s := schedule.NewSchedule(schedule.WithWindow(w), schedule.WithWorkdays(func(day time.Time) bool {
+		if day.Equal(holiday) || day.Equal(holiday.AddDate(0, 0, 1)) {
+			return false
+		}
+		return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+	}))
+	e, err := overtime.NewEngine(s, func(day time.Time) bool { return day.Equal(holiday) }, 21750)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.GetHourlyRate() != 125 {
+		t.Errorf("期望小时工资125, 实际 %v", e.GetHourlyRate())
+	}
+	if _, err := overtime.NewEngine(s, nil, -1); err == nil {
+		t.Errorf("月工资为负应报错")
+	}
+
+	at := func(m, d, h, min int) time.Time { return time.Date(2026, time.Month(m), d, h, min, 0, 0, loc) }
+	r := e.Compute(
+		// 周一提前半小时到，加班到20:00：工作日加班2.5小时
+		overtime.Span{Start: at(9, 28, 8, 30), End: at(9, 28, 20, 0)},
+		// 周三加班到次日01:00：工作日6小时（18:00-24:00）+ 10月1日法定假日1小时（拆开计算）
+		overtime.Span{Start: at(9, 30, 9, 0), End: at(10, 1, 1, 0)},
+		// 10月2日休息日4小时
+		overtime.Span{Start: at(10, 2, 10, 0), End: at(10, 2, 14, 0)},
+	)
+	want := [3]time.Duration{8*time.Hour + 30*time.Minute, 4 * time.Hour, time.Hour}
+	for c, d := range want {
+		if got := r.GetDuration(overtime.Category(c)); got != d {
+			t.Errorf("%s 期望 %s, 实际 %s", overtime.Category(c), d, got)
+		}
+	}
+	pay := 125 * (8.5*1.5 + 4*2 + 1*3)
+	if math.Abs(r.GetTotalPay()-pay) > 1e-9 || r.GetTotalDuration() != 13*time.Hour+30*time.Minute {
+		t.Errorf("期望 %v, 实际 %v %s", pay, r.GetTotalPay(), r)
+	}
+}
+
+// TestOvertimeBreaks 加班时不计薪的加班休息不算加班，没有加班到那时不扣
+func TestOvertimeBreaks(t *testing.T) {
+	w, _ := schedule.NewWindow("09:00", "17:30")
+	supper, _ := schedule.NewBreak("晚饭", "17:30", "18:00", false)
+	w, err := w.WithBreaks(supper.ForOvertime())
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, _ := overtime.NewEngine(schedule.NewSchedule(schedule.WithWindow(w)), nil, 21750)
+	at := func(h, min int) time.Time { return time.Date(2026, 9, 28, h, min, 0, 0, loc) }
+	cases := []struct {
+		end  time.Time
+		want time.Duration
+	}{
+		{at(17, 30), 0},
+		{at(17, 45), 0},
+		{at(20, 0), 2 * time.Hour},
+	}
+	for _, c := range cases {
+		r := e.Compute(overtime.Span{Start: at(9, 0), End: c.end})
+		if got := r.GetDuration(overtime.CategoryWorkday); got != c.want {
+			t.Errorf("%s下班 期望加班 %s, 实际 %s", c.end.Format("15:04"), c.want, got)
+		}
+	}
+}
+
+// TestOvernight 夜班跨零点时次日的上班时间不算加班
+func TestOvernight(t *testing.T) {
+	night, _ := schedule.NewWindow("20:00", "08:00")
+	r, _ := schedule.NewRotation(time.Date(2026, 9, 1, 0, 0, 0, 0, loc), night, schedule.Window{})
+	e, _ := overtime.NewEngine(schedule.NewSchedule(schedule.WithRotation(r)), nil, 21750)
+	// 9月1日夜班干到9月2日（休息）09:30：1.5小时接着夜班，为工作日加班
+	report := e.Compute(overtime.Span{Start: time.Date(2026, 9, 1, 20, 0, 0, 0, loc), End: time.Date(2026, 9, 2, 9, 30, 0, 0, loc)})
+	if d := report.GetDuration(overtime.CategoryWorkday); d != 90*time.Minute || report.GetDuration(overtime.CategoryRestDay) != 0 {
+		t.Errorf("期望工作日加班1.5小时, 实际 %s", report)
+	}
+}
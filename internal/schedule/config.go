@@ -0,0 +1,179 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// ============ 作息文件 ============
+
+// ConfigVersion 作息文件的格式版本
+const ConfigVersion = 1
+
+// Config 作息文件（schedule.json）的内容
+//
+//	{
+//	  "version": 1,
+//	  "weekdays": {"mon": {"start": "09:00", "end": "18:00", "breaks": [{"name": "午休", "start": "12:00", "end": "13:00"}, {"name": "晚饭", "start": "18:00", "end": "18:30", "overtime": true}]}, "sat": null},
+//	  "rotation": {"anchor": "2026-09-01", "pattern": [{"name": "白班", "start": "08:00", "end": "20:00"}, {"name": "夜班", "start": "20:00", "end": "08:00"}, null, null]},
+//	  "flex": {"earliest": "08:00", "latest": "10:00", "duration": "9h"}
+//	}
+//
+// weekdays中未列出的星期几保持默认，null表示休息；指定rotation时按轮班，忽略weekdays和flex
+type Config struct {
+	Version  int                      `json:"version"`
+	Weekdays map[string]*WindowConfig `json:"weekdays,omitempty"`
+	Rotation *RotationConfig          `json:"rotation,omitempty"`
+	Flex     *FlexConfig              `json:"flex,omitempty"`
+}
+
+// WindowConfig 工作时段（班次）定义
+type WindowConfig struct {
+	Name   string        `json:"name,omitempty"`
+	Start  string        `json:"start"`
+	End    string        `json:"end"`
+	Breaks []BreakConfig `json:"breaks,omitempty"`
+}
+
+// BreakConfig 休息定义，overtime为加班休息（在下班之后，只在加班时生效）
+type BreakConfig struct {
+	Name     string `json:"name"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Paid     bool   `json:"paid,omitempty"`
+	Overtime bool   `json:"overtime,omitempty"`
+}
+
+// RotationConfig 轮班定义，anchor为序列第一天（YYYY-MM-DD），pattern中的null表示休息
+type RotationConfig struct {
+	Anchor  string          `json:"anchor"`
+	Pattern []*WindowConfig `json:"pattern"`
+}
+
+// FlexConfig 弹性工作时间定义，duration为每天需工作的时长（含午休），如“9h”、“8h30m”
+type FlexConfig struct {
+	Earliest string `json:"earliest"`
+	Latest   string `json:"latest"`
+	Duration string `json:"duration"`
+}
+
+// weekdayKeys 作息文件中weekdays的键
+var weekdayKeys = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// LoadConfig 从JSON读取作息，返回对应的作息选项，loc为轮班起始日的时区
+// source用于错误信息，通常为文件名
+func LoadConfig(source string, r io.Reader, loc *time.Location) ([]Option, error) {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	var c Config
+	if err := dec.Decode(&c); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	opts, err := c.Options(loc)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	return opts, nil
+}
+
+// Options 把作息定义转换为作息选项
+func (c Config) Options(loc *time.Location) ([]Option, error) {
+	if c.Version != ConfigVersion {
+		return nil, fmt.Errorf("不支持的版本: %d，应为%d", c.Version, ConfigVersion)
+	}
+	var opts []Option
+	for key, wc := range c.Weekdays {
+		weekday, ok := weekdayKeys[key]
+		if !ok {
+			return nil, fmt.Errorf("weekdays: 非法星期: %q，应为mon、tue、wed、thu、fri、sat或sun", key)
+		}
+		if wc == nil {
+			opts = append(opts, WithDayOff(weekday))
+			continue
+		}
+		w, err := wc.Window()
+		if err != nil {
+			return nil, fmt.Errorf("weekdays.%s: %w", key, err)
+		}
+		opts = append(opts, WithWindow(w, weekday))
+	}
+	if c.Rotation != nil {
+		r, err := c.Rotation.Rotation(loc)
+		if err != nil {
+			return nil, fmt.Errorf("rotation: %w", err)
+		}
+		opts = append(opts, WithRotation(r))
+	}
+	if c.Flex != nil {
+		f, err := c.Flex.Flex()
+		if err != nil {
+			return nil, fmt.Errorf("flex: %w", err)
+		}
+		opts = append(opts, WithFlex(f))
+	}
+	return opts, nil
+}
+
+// Flex 把弹性工作时间定义转换为弹性工作时间
+func (c FlexConfig) Flex() (Flex, error) {
+	d, err := time.ParseDuration(c.Duration)
+	if err != nil {
+		return Flex{}, fmt.Errorf("非法工作时长: %q，格式应如 9h、8h30m", c.Duration)
+	}
+	return NewFlex(c.Earliest, c.Latest, d)
+}
+
+// Window 把时段定义转换为工作时段
+func (c WindowConfig) Window() (Window, error) {
+	w, err := NewWindow(c.Start, c.End)
+	if err != nil {
+		return Window{}, err
+	}
+	breaks := make([]Break, 0, len(c.Breaks))
+	for _, bc := range c.Breaks {
+		if bc.Name == "" {
+			return Window{}, fmt.Errorf("休息缺少名称")
+		}
+		b, err := NewBreak(bc.Name, bc.Start, bc.End, bc.Paid)
+		if err != nil {
+			return Window{}, fmt.Errorf("%s: %w", bc.Name, err)
+		}
+		if bc.Overtime {
+			b = b.ForOvertime()
+		}
+		breaks = append(breaks, b)
+	}
+	w, err = w.WithBreaks(breaks...)
+	if err != nil {
+		return Window{}, err
+	}
+	return w.WithName(c.Name), nil
+}
+
+// Rotation 把轮班定义转换为轮班
+func (c RotationConfig) Rotation(loc *time.Location) (Rotation, error) {
+	anchor, err := time.ParseInLocation(time.DateOnly, c.Anchor, loc)
+	if err != nil {
+		return Rotation{}, fmt.Errorf("非法起始日: %q，格式应为 YYYY-MM-DD", c.Anchor)
+	}
+	pattern := make([]Window, len(c.Pattern))
+	for i, wc := range c.Pattern {
+		if wc == nil {
+			continue
+		}
+		if pattern[i], err = wc.Window(); err != nil {
+			return Rotation{}, fmt.Errorf("pattern[%d]: %w", i, err)
+		}
+	}
+	return NewRotation(anchor, pattern...)
+}
@@ -0,0 +1,141 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+)
+
+// ============ 弹性工作时间 ============
+
+// Arrivals 到岗记录，获取某天的到岗时刻，没有记录时ok为false，day为当天零点
+type Arrivals func(day time.Time) (arrival time.Time, ok bool)
+
+// Flex 弹性工作时间：在earliest至latest之间到岗，到岗后工作满duration（含午休）即可下班
+// 早于earliest到岗按earliest起算，晚于latest到岗为迟到，仍按实际到岗起算
+type Flex struct {
+	earliest time.Duration
+	latest   time.Duration
+	duration time.Duration
+}
+
+// NewFlex 创建弹性工作时间，如 NewFlex("08:00", "10:00", 9*time.Hour)
+func NewFlex(earliest string, latest string, duration time.Duration) (Flex, error) {
+	e, err := ParseTimeOfDay(earliest)
+	if err != nil {
+		return Flex{}, err
+	}
+	l, err := ParseTimeOfDay(latest)
+	if err != nil {
+		return Flex{}, err
+	}
+	if l < e {
+		return Flex{}, fmt.Errorf("非法到岗时段: %s-%s", earliest, latest)
+	}
+	if duration <= 0 || duration > 24*time.Hour {
+		return Flex{}, fmt.Errorf("非法工作时长: %s", duration)
+	}
+	return Flex{earliest: e, latest: l, duration: duration}, nil
+}
+
+// GetEarliest 获取最早到岗时刻（距零点的时长）
+func (o Flex) GetEarliest() time.Duration { return o.earliest }
+
+// GetLatest 获取最晚到岗时刻（距零点的时长）
+func (o Flex) GetLatest() time.Duration { return o.latest }
+
+// GetDuration 获取每天需工作的时长（含休息）
+func (o Flex) GetDuration() time.Duration { return o.duration }
+
+// IsLate 按到岗时刻（距零点的时长）判断是否迟到
+func (o Flex) IsLate(arrival time.Duration) bool { return arrival > o.latest }
+
+// WindowFor 按到岗时刻（距零点的时长）获取当天的工作时段，保留base中落在时段内的休息（如午休）
+func (o Flex) WindowFor(arrival time.Duration, base Window) Window {
+	start := max(arrival, o.earliest)
+	w := Window{name: base.name, start: start, end: start + o.duration}
+	for _, b := range base.breaks {
+		if b.start >= w.start && b.end <= w.end {
+			w.breaks = append(w.breaks, b)
+		}
+	}
+	return w
+}
+
+// String 字符串表示，如“08:00-10:00到岗 工作9h0m0s”
+func (o Flex) String() string {
+	return FormatTimeOfDay(o.earliest) + "-" + FormatTimeOfDay(o.latest) + "到岗 工作" + o.duration.String()
+}
+
+// ============ 弹性工作状态 ============
+
+// FlexStatus 弹性工作时间下当天的到岗、应下班时刻和早退差额
+type FlexStatus struct {
+	at       time.Time
+	arrival  time.Time
+	recorded bool
+	late     bool
+	start    time.Time
+	leave    time.Time
+}
+
+// GetFlexStatus 获取t当天的弹性工作状态，不是弹性工作时间（含轮班）或当天不上班时返回nil
+// 没有到岗记录时按最晚到岗时刻计算
+func (o *Schedule) GetFlexStatus(t time.Time) *FlexStatus {
+	if o.flex == nil || o.rotation != nil {
+		return nil
+	}
+	day := startOfDay(t)
+	w := o.WindowOn(day)
+	if w == nil {
+		return nil
+	}
+	s := &FlexStatus{at: t}
+	s.arrival, s.recorded = o.arrivalOn(day)
+	if !s.recorded {
+		s.arrival = day.Add(o.flex.latest)
+	}
+	s.late = o.flex.IsLate(s.arrival.Sub(day))
+	s.start, s.leave = w.On(day)
+	return s
+}
+
+// arrivalOn 获取某天的到岗时刻，没有记录或不在当天时ok为false
+func (o *Schedule) arrivalOn(day time.Time) (time.Time, bool) {
+	if o.arrivals == nil {
+		return time.Time{}, false
+	}
+	arrival, ok := o.arrivals(day)
+	if !ok || !startOfDay(arrival.In(day.Location())).Equal(day) {
+		return time.Time{}, false
+	}
+	return arrival.In(day.Location()), true
+}
+
+// GetArrival 获取到岗时刻，没有记录时为最晚到岗时刻
+func (o FlexStatus) GetArrival() time.Time { return o.arrival }
+
+// IsRecorded 是否有到岗记录
+func (o FlexStatus) IsRecorded() bool { return o.recorded }
+
+// IsLate 是否迟到（晚于最晚到岗时刻）
+func (o FlexStatus) IsLate() bool { return o.late }
+
+// GetStart 获取起算时刻，早于最早到岗时刻到岗时按最早到岗时刻
+func (o FlexStatus) GetStart() time.Time { return o.start }
+
+// GetLeaveTime 获取应下班时刻
+func (o FlexStatus) GetLeaveTime() time.Time { return o.leave }
+
+// GetWorked 获取到t为止已工作的时长（含休息），未到起算时刻为0
+func (o FlexStatus) GetWorked() time.Duration { return max(0, o.at.Sub(o.start)) }
+
+// GetBalance 获取早退差额：现在下班比应下班时刻早（负数）或晚（正数）多少，未到起算时刻为0
+func (o FlexStatus) GetBalance() time.Duration {
+	if o.at.Before(o.start) {
+		return 0
+	}
+	return o.at.Sub(o.leave)
+}
+
+// IsShort 现在下班是否不满工作时长（早退）
+func (o FlexStatus) IsShort() bool { return o.GetBalance() < 0 }
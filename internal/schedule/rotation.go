@@ -0,0 +1,43 @@
+package schedule
+
+import (
+	"errors"
+	"time"
+)
+
+// ============ 轮班 ============
+
+// Rotation 轮班：从起始日开始按班次序列逐日循环，如早早中中夜夜休休、上四休二
+type Rotation struct {
+	anchor  time.Time
+	pattern []Window
+}
+
+// NewRotation 创建轮班，anchor为序列第一天（按所在时区取日期），pattern中的零值Window表示休息
+func NewRotation(anchor time.Time, pattern ...Window) (Rotation, error) {
+	if len(pattern) == 0 {
+		return Rotation{}, errors.New("轮班序列不能为空")
+	}
+	return Rotation{anchor: startOfDay(anchor), pattern: pattern}, nil
+}
+
+// GetAnchor 获取起始日（零点）
+func (o Rotation) GetAnchor() time.Time { return o.anchor }
+
+// GetPattern 获取班次序列
+func (o Rotation) GetPattern() []Window { return o.pattern }
+
+// WindowOn 获取某天的班次，休息返回nil；起始日之前按序列倒推
+func (o Rotation) WindowOn(day time.Time) *Window {
+	day = startOfDay(day)
+	// 按日期算天数差，不受夏令时影响
+	y1, m1, d1 := o.anchor.Date()
+	y2, m2, d2 := day.In(o.anchor.Location()).Date()
+	days := int(time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC).Sub(time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)) / (24 * time.Hour))
+	n := len(o.pattern)
+	w := o.pattern[(days%n+n)%n]
+	if w.IsZero() {
+		return nil
+	}
+	return &w
+}
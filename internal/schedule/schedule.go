@@ -0,0 +1,284 @@
+package schedule
+
+import (
+	"time"
+)
+
+// ============ 每周作息 ============
+
+// maxLookahead 查找下一个上班日的最大天数（如春节长假）
+const maxLookahead = 366
+
+// Workdays 工作日历，判断某天是否上班（如法定节假日放假、调休上班），day为当天零点
+type Workdays func(day time.Time) bool
+
+// Schedule 作息：每周星期几在什么时段上班，或者按轮班
+type Schedule struct {
+	windows  [7]*Window
+	workdays Workdays
+	rotation *Rotation
+	flex     *Flex
+	arrivals Arrivals
+}
+
+// Option 作息选项
+type Option func(*Schedule)
+
+// WithWindow 指定星期几的工作时段，不指定weekdays时为周一至周五
+func WithWindow(window Window, weekdays ...time.Weekday) Option {
+	if len(weekdays) == 0 {
+		weekdays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+	}
+	return func(o *Schedule) {
+		for _, w := range weekdays {
+			o.windows[w] = &window
+		}
+	}
+}
+
+// WithDayOff 指定星期几休息
+func WithDayOff(weekdays ...time.Weekday) Option {
+	return func(o *Schedule) {
+		for _, w := range weekdays {
+			o.windows[w] = nil
+		}
+	}
+}
+
+// WithWorkdays 指定工作日历，由其决定某天是否上班，上班日按当天星期几的时段，没有则按标准时段（见 GetStandardWindow）
+func WithWorkdays(workdays Workdays) Option {
+	return func(o *Schedule) {
+		o.workdays = workdays
+	}
+}
+
+// WithRotation 按轮班上班，优先于每周时段和工作日历（轮班不受法定节假日影响）
+func WithRotation(rotation Rotation) Option {
+	return func(o *Schedule) {
+		o.rotation = &rotation
+	}
+}
+
+// WithFlex 按弹性工作时间上班：上班日的时段从当天到岗时刻起算（见 Flex），轮班时不生效
+// 到岗时刻由 WithArrivals 提供，没有记录时按最晚到岗时刻
+func WithFlex(flex Flex) Option {
+	return func(o *Schedule) {
+		o.flex = &flex
+	}
+}
+
+// WithArrivals 指定到岗记录，用于弹性工作时间
+func WithArrivals(arrivals Arrivals) Option {
+	return func(o *Schedule) {
+		o.arrivals = arrivals
+	}
+}
+
+// NewSchedule 创建作息，默认周一至周五 09:00-19:00
+func NewSchedule(opts ...Option) *Schedule {
+	o := &Schedule{}
+	WithWindow(DefaultWindow)(o)
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// GetWindow 获取星期几的工作时段，休息返回nil
+func (o *Schedule) GetWindow(weekday time.Weekday) *Window {
+	return o.windows[weekday]
+}
+
+// GetStandardWindow 获取标准工作时段：从周一起第一个有工作时段的那天，用于调休上班等星期几本身不上班的日子
+func (o *Schedule) GetStandardWindow() Window {
+	for i := 1; i <= 7; i++ {
+		if w := o.windows[i%7]; w != nil {
+			return *w
+		}
+	}
+	return DefaultWindow
+}
+
+// GetRotation 获取轮班，不是轮班返回nil
+func (o *Schedule) GetRotation() *Rotation {
+	return o.rotation
+}
+
+// GetFlex 获取弹性工作时间，不是弹性工作时间返回nil
+func (o *Schedule) GetFlex() *Flex {
+	return o.flex
+}
+
+// WindowOn 获取某天开始的工作时段，休息返回nil
+func (o *Schedule) WindowOn(day time.Time) *Window {
+	day = startOfDay(day)
+	if o.rotation != nil {
+		return o.rotation.WindowOn(day)
+	}
+	w := o.windows[day.Weekday()]
+	if o.workdays != nil {
+		if !o.workdays(day) {
+			return nil
+		}
+		if w == nil {
+			standard := o.GetStandardWindow()
+			w = &standard
+		}
+	}
+	if w == nil || o.flex == nil {
+		return w
+	}
+	arrival := o.flex.latest
+	if t, ok := o.arrivalOn(day); ok {
+		arrival = t.Sub(day)
+	}
+	flex := o.flex.WindowFor(arrival, *w)
+	return &flex
+}
+
+// ============ 工作状态 ============
+
+// Phase 一天中所处的阶段
+type Phase int
+
+const (
+	PhaseBeforeWork Phase = iota // 未上班
+	PhaseWorking                 // 上班中
+	PhaseAfterWork               // 已下班
+	PhaseDayOff                  // 休息日
+	PhaseBreak                   // 工作时段中的休息，如午休
+)
+
+// PhaseNames 阶段名称
+var PhaseNames = []string{"未上班", "上班中", "已下班", "休息日", "休息中"}
+
+// String 获取名称
+func (p Phase) String() string {
+	return PhaseNames[p]
+}
+
+// Status 某一时刻的工作状态
+type Status struct {
+	phase     Phase
+	at        time.Time
+	window    Window
+	start     time.Time
+	end       time.Time
+	next      time.Time
+	current   *Break
+	nextBreak *Break
+}
+
+// GetStatus 获取某一时刻的工作状态，按t所在时区的钟面时间计算
+// 前一天的夜班跨过零点还没结束时，处于该班次中；结束后当天没有班次时为已下班
+func (o *Schedule) GetStatus(t time.Time) Status {
+	s := Status{phase: PhaseDayOff, at: t}
+	day := startOfDay(t)
+	yesterday := day.AddDate(0, 0, -1)
+	if w := o.WindowOn(yesterday); w != nil && w.IsOvernight() {
+		start, end := w.On(yesterday)
+		if t.Before(end) {
+			s.working(*w, start, end)
+			return s
+		}
+		if o.WindowOn(day) == nil {
+			s.phase, s.window, s.start, s.end = PhaseAfterWork, *w, start, end
+			s.current = w.overtimeBreakAt(w.start + t.Sub(start))
+		}
+	}
+	if w := o.WindowOn(day); w != nil {
+		s.window = *w
+		s.start, s.end = w.On(day)
+		switch {
+		case t.Before(s.start):
+			s.phase, s.next = PhaseBeforeWork, s.start
+			return s
+		case t.Before(s.end):
+			s.working(*w, s.start, s.end)
+			return s
+		default:
+			s.phase = PhaseAfterWork
+			s.current = w.overtimeBreakAt(w.start + t.Sub(s.start))
+		}
+	}
+	// 下一次变化是下一个班次的上班时刻
+	if _, start, ok := o.GetNextShift(t); ok {
+		s.next = start
+	}
+	return s
+}
+
+// working 设置上班中（含休息）的状态：下一次变化是下一段休息开始或下班；休息中则是休息结束
+func (s *Status) working(w Window, start time.Time, end time.Time) {
+	s.phase, s.window, s.start, s.end, s.next = PhaseWorking, w, start, end, end
+	offset := w.start + s.at.Sub(start)
+	for _, b := range w.breaks {
+		if offset < b.start {
+			s.nextBreak, s.next = &b, start.Add(b.start-w.start)
+			return
+		}
+		if offset < b.end {
+			s.phase, s.current, s.next = PhaseBreak, &b, start.Add(b.end-w.start)
+			return
+		}
+	}
+}
+
+// GetNextShift 获取t之后（不含正在进行的）下一个班次及其上班时刻，一年内没有班次时ok为false
+func (o *Schedule) GetNextShift(t time.Time) (Window, time.Time, bool) {
+	day := startOfDay(t)
+	for i := 0; i <= maxLookahead; i++ {
+		d := day.AddDate(0, 0, i)
+		if w := o.WindowOn(d); w != nil {
+			if start, _ := w.On(d); start.After(t) {
+				return *w, start, true
+			}
+		}
+	}
+	return Window{}, time.Time{}, false
+}
+
+// GetPhase 获取阶段
+func (o Status) GetPhase() Phase { return o.phase }
+
+// GetStart 获取当天上班时刻，休息日为零值
+func (o Status) GetStart() time.Time { return o.start }
+
+// GetEnd 获取当天下班时刻，休息日为零值
+func (o Status) GetEnd() time.Time { return o.end }
+
+// GetBreak 获取当前所处的休息，已下班时为所处的加班休息，不在休息中返回nil
+func (o Status) GetBreak() *Break { return o.current }
+
+// GetNextBreak 上班中且下一次变化是开始休息时，获取该休息，否则返回nil
+func (o Status) GetNextBreak() *Break { return o.nextBreak }
+
+// GetWindow 获取当天的工作时段，休息日为零值
+func (o Status) GetWindow() Window { return o.window }
+
+// GetNext 获取下一次状态变化的时刻（上班、下班、开始或结束休息），找不到时为零值
+func (o Status) GetNext() time.Time { return o.next }
+
+// GetTimeToNext 距离下一次状态变化的时长，找不到时为0
+func (o Status) GetTimeToNext() time.Duration {
+	if o.next.IsZero() {
+		return 0
+	}
+	return o.next.Sub(o.at)
+}
+
+// GetProgress 获取当天工作进度（按计薪时长，不计薪的休息期间暂停）：未上班为0，已下班为1，休息日为0
+func (o Status) GetProgress() float64 {
+	switch o.phase {
+	case PhaseWorking, PhaseBreak:
+		paid := o.window.GetPaidDuration()
+		if paid == 0 {
+			return 0
+		}
+		offset := o.window.start + o.at.Sub(o.start)
+		return float64(o.window.paidBefore(offset)) / float64(paid)
+	case PhaseAfterWork:
+		return 1
+	}
+	return 0
+}
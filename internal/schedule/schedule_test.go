@@ -0,0 +1,316 @@
+package schedule_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"workoff-timer/internal/schedule"
+)
+
+var loc = time.FixedZone("CST", 8*3600)
+
+// TestWindow 工作时段解析测试
+func TestWindow(t *testing.T) {
+	w, err := schedule.NewWindow("08:30", "17:30")
+	if err != nil || w.String() != "08:30-17:30" || w.GetDuration() != 9*time.Hour {
+		t.Errorf("期望08:30-17:30, 实际 %s %v", w, err)
+	}
+	for _, c := range [][2]string{{"9:00", "18:00"}, {"09:00", "24:30"}, {"09:00", "09:00"}, {"09:60", "18:00"}, {"+9:00", "18:00"}} {
+		if _, err := schedule.NewWindow(c[0], c[1]); err == nil {
+			t.Errorf("%s-%s 应为非法时段", c[0], c[1])
+		}
+	}
+	// 下班早于上班为跨零点的夜班
+	night, err := schedule.NewWindow("22:00", "06:00")
+	if err != nil || !night.IsOvernight() || night.String() != "22:00-06:00" || night.GetDuration() != 8*time.Hour {
+		t.Errorf("期望夜班22:00-06:00, 实际 %s %v", night, err)
+	}
+}
+
+// TestStatus 工作状态测试
+func TestStatus(t *testing.T) {
+	s := schedule.NewSchedule()
+	cases := []struct {
+		at       time.Time
+		phase    schedule.Phase
+		next     time.Time
+		progress float64
+	}{
+		// 2026-09-21 星期一
+		{time.Date(2026, 9, 21, 8, 0, 0, 0, loc), schedule.PhaseBeforeWork, time.Date(2026, 9, 21, 9, 0, 0, 0, loc), 0},
+		{time.Date(2026, 9, 21, 9, 0, 0, 0, loc), schedule.PhaseWorking, time.Date(2026, 9, 21, 19, 0, 0, 0, loc), 0},
+		{time.Date(2026, 9, 21, 14, 0, 0, 0, loc), schedule.PhaseWorking, time.Date(2026, 9, 21, 19, 0, 0, 0, loc), 0.5},
+		{time.Date(2026, 9, 21, 19, 0, 0, 0, loc), schedule.PhaseAfterWork, time.Date(2026, 9, 22, 9, 0, 0, 0, loc), 1},
+		// 周五下班后到周一上班
+		{time.Date(2026, 9, 25, 20, 0, 0, 0, loc), schedule.PhaseAfterWork, time.Date(2026, 9, 28, 9, 0, 0, 0, loc), 1},
+		{time.Date(2026, 9, 26, 10, 0, 0, 0, loc), schedule.PhaseDayOff, time.Date(2026, 9, 28, 9, 0, 0, 0, loc), 0},
+	}
+	for _, c := range cases {
+		st := s.GetStatus(c.at)
+		if st.GetPhase() != c.phase || !st.GetNext().Equal(c.next) || st.GetProgress() != c.progress {
+			t.Errorf("%s 期望 %s %s %v, 实际 %s %s %v", c.at, c.phase, c.next, c.progress, st.GetPhase(), st.GetNext(), st.GetProgress())
+		}
+		if st.GetTimeToNext() != c.next.Sub(c.at) {
+			t.Errorf("%s 期望 %s, 实际 %s", c.at, c.next.Sub(c.at), st.GetTimeToNext())
+		}
+	}
+
+	// 按星期指定时段，工作日历决定放假和调休上班
+	friday, _ := schedule.NewWindow("09:00", "17:00")
+	holiday := time.Date(2026, 10, 1, 0, 0, 0, 0, loc)
+	makeup := time.Date(2026, 10, 10, 0, 0, 0, 0, loc)
+	s = schedule.NewSchedule(
+		schedule.WithWindow(friday, time.Friday),
+		schedule.WithWorkdays(func(day time.Time) bool {
+			if day.Equal(holiday) {
+				return false
+			}
+			if day.Equal(makeup) {
+				return true
+			}
+			return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+		}),
+	)
+	if st := s.GetStatus(time.Date(2026, 9, 30, 20, 0, 0, 0, loc)); !st.GetNext().Equal(time.Date(2026, 10, 2, 9, 0, 0, 0, loc)) {
+		t.Errorf("期望10月2日上班, 实际 %s", st.GetNext())
+	}
+	if st := s.GetStatus(time.Date(2026, 10, 2, 16, 0, 0, 0, loc)); !st.GetNext().Equal(time.Date(2026, 10, 2, 17, 0, 0, 0, loc)) {
+		t.Errorf("期望周五17:00下班, 实际 %s", st.GetNext())
+	}
+	if st := s.GetStatus(makeup.Add(10 * time.Hour)); st.GetPhase() != schedule.PhaseWorking || st.GetEnd().Hour() != 19 {
+		t.Errorf("期望调休周六按标准时段上班, 实际 %s %s", st.GetPhase(), st.GetEnd())
+	}
+}
+
+// TestBreaks 午休、晚饭等休息测试
+func TestBreaks(t *testing.T) {
+	dinner, _ := schedule.NewBreak("晚饭", "17:30", "18:00", true)
+	w, err := schedule.NewWindow("09:00", "21:00")
+	if err == nil {
+		w, err = w.WithBreaks(dinner, schedule.LunchBreak)
+	}
+	if err != nil || len(w.GetBreaks()) != 2 || w.GetBreaks()[0].GetName() != "午休" {
+		t.Fatalf("期望午休、晚饭, 实际 %v %v", w.GetBreaks(), err)
+	}
+	// 午休不计薪，晚饭计薪
+	if d := w.GetPaidDuration(); d != 10*time.Hour+30*time.Minute {
+		t.Errorf("期望计薪10.5小时, 实际 %s", d)
+	}
+	overlap, _ := schedule.NewBreak("茶歇", "13:00", "13:15", true)
+	if _, err := w.WithBreaks(overlap); err == nil {
+		t.Errorf("休息重叠应报错")
+	}
+	outside, _ := schedule.NewBreak("夜宵", "21:00", "21:30", true)
+	if _, err := w.WithBreaks(outside); err == nil {
+		t.Errorf("休息在工作时段之外应报错")
+	}
+	// 不计薪的休息占满工作时段时报错；弹性工作时间下按到岗时刻得到的时段仍可能被占满，进度为0
+	lunch, _ := schedule.NewWindow("12:00", "13:30")
+	if _, err := lunch.WithBreaks(schedule.LunchBreak); err == nil {
+		t.Errorf("午休占满工作时段应报错")
+	}
+	short, _ := schedule.NewFlex("12:00", "12:00", 90*time.Minute)
+	if st := schedule.NewSchedule(schedule.WithWindow(w), schedule.WithFlex(short)).GetStatus(time.Date(2026, 9, 21, 12, 30, 0, 0, loc)); st.GetPhase() != schedule.PhaseBreak || st.GetProgress() != 0 {
+		t.Errorf("期望午休中进度为0, 实际 %s %v", st.GetPhase(), st.GetProgress())
+	}
+
+	s := schedule.NewSchedule(schedule.WithWindow(w))
+	day := func(h, m int) time.Time { return time.Date(2026, 9, 21, h, m, 0, 0, loc) }
+	cases := []struct {
+		at       time.Time
+		phase    schedule.Phase
+		brk      string
+		next     string
+		toNext   time.Duration
+		progress time.Duration
+	}{
+		{day(11, 36), schedule.PhaseWorking, "", "午休", 24 * time.Minute, 2*time.Hour + 36*time.Minute},
+		{day(12, 30), schedule.PhaseBreak, "午休", "", time.Hour, 3 * time.Hour},
+		{day(13, 30), schedule.PhaseWorking, "", "晚饭", 4 * time.Hour, 3 * time.Hour},
+		{day(17, 45), schedule.PhaseBreak, "晚饭", "", 15 * time.Minute, 7*time.Hour + 15*time.Minute},
+		{day(20, 0), schedule.PhaseWorking, "", "", time.Hour, 9*time.Hour + 30*time.Minute},
+	}
+	for _, c := range cases {
+		st := s.GetStatus(c.at)
+		var brk, next string
+		if b := st.GetBreak(); b != nil {
+			brk = b.GetName()
+		}
+		if b := st.GetNextBreak(); b != nil {
+			next = b.GetName()
+		}
+		progress := float64(c.progress) / float64(w.GetPaidDuration())
+		if st.GetPhase() != c.phase || brk != c.brk || next != c.next || st.GetTimeToNext() != c.toNext || st.GetProgress() != progress {
+			t.Errorf("%s 期望 %s %q %q %s %v, 实际 %s %q %q %s %v", c.at.Format("15:04"), c.phase, c.brk, c.next, c.toNext, progress,
+				st.GetPhase(), brk, next, st.GetTimeToNext(), st.GetProgress())
+		}
+	}
+
+	// 加班休息在下班之后，不影响正常上班的计薪时长，加班时处于其中
+	early, _ := schedule.NewWindow("09:00", "17:30")
+	supper, _ := schedule.NewBreak("晚饭", "17:30", "18:00", false)
+	if _, err := early.WithBreaks(schedule.LunchBreak.ForOvertime()); err == nil {
+		t.Errorf("加班休息在下班之前应报错")
+	}
+	early, err = early.WithBreaks(schedule.LunchBreak, supper.ForOvertime())
+	if err != nil || len(early.GetBreaks()) != 1 || len(early.GetOvertimeBreaks()) != 1 || early.GetPaidDuration() != 7*time.Hour {
+		t.Fatalf("期望午休和加班晚饭, 实际 %v %v %v", early.GetBreaks(), early.GetOvertimeBreaks(), err)
+	}
+	s = schedule.NewSchedule(schedule.WithWindow(early))
+	if st := s.GetStatus(day(17, 40)); st.GetPhase() != schedule.PhaseAfterWork || st.GetBreak() == nil || st.GetBreak().GetName() != "晚饭" {
+		t.Errorf("17:40 期望已下班、加班晚饭中, 实际 %s %v", st.GetPhase(), st.GetBreak())
+	}
+	if st := s.GetStatus(day(18, 0)); st.GetBreak() != nil {
+		t.Errorf("18:00 期望晚饭已结束, 实际 %v", st.GetBreak())
+	}
+}
+
+// TestRotation 轮班、夜班测试
+func TestRotation(t *testing.T) {
+	meal, _ := schedule.NewBreak("夜宵", "02:00", "02:30", false)
+	day, _ := schedule.NewWindow("08:00", "20:00")
+	night, _ := schedule.NewWindow("20:00", "08:00")
+	night, err := night.WithBreaks(meal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2026-09-01起：白班、夜班、休、休
+	r, err := schedule.NewRotation(time.Date(2026, 9, 1, 0, 0, 0, 0, loc), day.WithName("白班"), night.WithName("夜班"), schedule.Window{}, schedule.Window{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := schedule.NewRotation(time.Now()); err == nil {
+		t.Errorf("空轮班序列应报错")
+	}
+	s := schedule.NewSchedule(schedule.WithRotation(r))
+	at := func(d, h, m int) time.Time { return time.Date(2026, 9, d, h, m, 0, 0, loc) }
+	cases := []struct {
+		at    time.Time
+		phase schedule.Phase
+		shift string
+		next  time.Time
+	}{
+		{at(1, 7, 0), schedule.PhaseBeforeWork, "白班", at(1, 8, 0)},
+		{at(1, 21, 0), schedule.PhaseAfterWork, "白班", at(2, 20, 0)},
+		{at(2, 23, 0), schedule.PhaseWorking, "夜班", at(3, 2, 0)},
+		// 夜班跨过零点仍在上班
+		{at(3, 2, 10), schedule.PhaseBreak, "夜班", at(3, 2, 30)},
+		{at(3, 5, 0), schedule.PhaseWorking, "夜班", at(3, 8, 0)},
+		// 夜班下班后当天休息，仍算已下班
+		{at(3, 9, 0), schedule.PhaseAfterWork, "夜班", at(5, 8, 0)},
+		{at(4, 12, 0), schedule.PhaseDayOff, "", at(5, 8, 0)},
+		// 起始日之前按序列倒推：8月31日休息
+		{time.Date(2026, 8, 31, 12, 0, 0, 0, loc), schedule.PhaseDayOff, "", at(1, 8, 0)},
+	}
+	for _, c := range cases {
+		st := s.GetStatus(c.at)
+		if st.GetPhase() != c.phase || st.GetWindow().GetName() != c.shift || !st.GetNext().Equal(c.next) {
+			t.Errorf("%s 期望 %s %q %s, 实际 %s %q %s", c.at, c.phase, c.shift, c.next, st.GetPhase(), st.GetWindow().GetName(), st.GetNext())
+		}
+	}
+	// 夜宵不计薪：23:00时已工作3小时
+	if p := s.GetStatus(at(2, 23, 0)).GetProgress(); p != 3.0/11.5 {
+		t.Errorf("期望进度 %v, 实际 %v", 3.0/11.5, p)
+	}
+	if w, start, ok := s.GetNextShift(at(2, 23, 0)); !ok || w.GetName() != "白班" || !start.Equal(at(5, 8, 0)) {
+		t.Errorf("期望下一班白班 9月5日08:00, 实际 %q %s %v", w.GetName(), start, ok)
+	}
+}
+
+// TestLoadConfig 作息文件测试
+func TestLoadConfig(t *testing.T) {
+	opts, err := schedule.LoadConfig("schedule.json", strings.NewReader(`{
+		"version": 1,
+		"weekdays": {"fri": {"start": "09:00", "end": "17:00", "breaks": [{"name": "晚饭", "start": "17:00", "end": "17:30", "overtime": true}]}, "sat": {"start": "10:00", "end": "16:00", "breaks": [{"name": "午休", "start": "12:00", "end": "13:00"}]}, "mon": null}
+	}`), loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := schedule.NewSchedule(opts...)
+	if s.GetWindow(time.Monday) != nil || s.GetWindow(time.Tuesday).String() != "09:00-19:00" || s.GetWindow(time.Friday).String() != "09:00-17:00" ||
+		len(s.GetWindow(time.Friday).GetOvertimeBreaks()) != 1 || s.GetWindow(time.Saturday).GetPaidDuration() != 5*time.Hour {
+		t.Errorf("作息与配置不符: %v %v %v %v", s.GetWindow(time.Monday), s.GetWindow(time.Tuesday), s.GetWindow(time.Friday), s.GetWindow(time.Saturday))
+	}
+
+	opts, err = schedule.LoadConfig("schedule.json", strings.NewReader(`{
+		"version": 1,
+		"rotation": {"anchor": "2026-09-01", "pattern": [{"name": "夜班", "start": "22:00", "end": "06:00"}, null]}
+	}`), loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = schedule.NewSchedule(opts...)
+	if w := s.WindowOn(time.Date(2026, 9, 3, 0, 0, 0, 0, loc)); w == nil || w.GetName() != "夜班" || s.WindowOn(time.Date(2026, 9, 4, 0, 0, 0, 0, loc)) != nil {
+		t.Errorf("期望9月3日夜班、9月4日休息, 实际 %v", w)
+	}
+
+	for _, c := range []string{
+		`{"version": 2}`,
+		`{"version": 1, "weekdays": {"monday": null}}`,
+		`{"version": 1, "weekdays": {"mon": {"start": "9:00", "end": "18:00"}}}`,
+		`{"version": 1, "weekdays": {"mon": {"start": "09:00", "end": "18:00", "breaks": [{"name": "午休", "start": "19:00", "end": "20:00"}]}}}`,
+		`{"version": 1, "weekdays": {"mon": {"start": "09:00", "end": "18:00", "breaks": [{"name": "晚饭", "start": "17:30", "end": "18:00", "overtime": true}]}}}`,
+		`{"version": 1, "rotation": {"anchor": "2026/09/01", "pattern": [null]}}`,
+		`{"version": 1, "rotation": {"anchor": "2026-09-01", "pattern": []}}`,
+		`{"version": 1, "shifts": []}`,
+	} {
+		if _, err := schedule.LoadConfig("schedule.json", strings.NewReader(c), loc); err == nil {
+			t.Errorf("%s 应报错", c)
+		}
+	}
+}
+
+// TestFlex 弹性工作时间测试
+func TestFlex(t *testing.T) {
+	f, err := schedule.NewFlex("08:00", "10:00", 9*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := schedule.NewFlex("10:00", "08:00", 9*time.Hour); err == nil {
+		t.Errorf("最晚到岗早于最早到岗应报错")
+	}
+	w, _ := schedule.DefaultWindow.WithBreaks(schedule.LunchBreak)
+	arrivals := map[int]time.Time{
+		21: time.Date(2026, 9, 21, 9, 12, 0, 0, loc),
+		22: time.Date(2026, 9, 22, 7, 40, 0, 0, loc),
+		23: time.Date(2026, 9, 23, 10, 30, 0, 0, loc),
+	}
+	s := schedule.NewSchedule(schedule.WithWindow(w), schedule.WithFlex(f), schedule.WithArrivals(func(day time.Time) (time.Time, bool) {
+		a, ok := arrivals[day.Day()]
+		return a, ok
+	}))
+	at := func(d, h, m int) time.Time { return time.Date(2026, 9, d, h, m, 0, 0, loc) }
+	cases := []struct {
+		at       time.Time
+		recorded bool
+		late     bool
+		leave    time.Time
+		balance  time.Duration
+	}{
+		{at(21, 17, 0), true, false, at(21, 18, 12), -72 * time.Minute},
+		// 早于8:00到岗按8:00起算
+		{at(22, 17, 30), true, false, at(22, 17, 0), 30 * time.Minute},
+		{at(23, 19, 0), true, true, at(23, 19, 30), -30 * time.Minute},
+		// 没有到岗记录按最晚到岗时刻
+		{at(24, 9, 0), false, false, at(24, 19, 0), 0},
+	}
+	for _, c := range cases {
+		fs := s.GetFlexStatus(c.at)
+		if fs == nil || fs.IsRecorded() != c.recorded || fs.IsLate() != c.late || !fs.GetLeaveTime().Equal(c.leave) || fs.GetBalance() != c.balance {
+			t.Errorf("%s 期望 %v %v %s %s, 实际 %+v", c.at, c.recorded, c.late, c.leave, c.balance, fs)
+			continue
+		}
+		if fs.IsShort() != (c.balance < 0) {
+			t.Errorf("%s 期望早退 %v", c.at, c.balance < 0)
+		}
+	}
+	// 下班倒计时按到岗时刻，午休仍不计薪
+	st := s.GetStatus(at(21, 17, 0))
+	if st.GetPhase() != schedule.PhaseWorking || !st.GetEnd().Equal(at(21, 18, 12)) || st.GetWindow().GetPaidDuration() != 7*time.Hour+30*time.Minute {
+		t.Errorf("期望18:12下班, 实际 %s %s %s", st.GetPhase(), st.GetEnd(), st.GetWindow())
+	}
+	if s.GetFlexStatus(at(26, 10, 0)) != nil {
+		t.Errorf("周六不上班应返回nil")
+	}
+}
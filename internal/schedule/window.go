@@ -0,0 +1,272 @@
+package schedule
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ============ 工作时段 ============
+
+// Window 一天中的工作时段（班次），start、end为距当天零点的时长，中间可以有休息（如午休）
+// 夜班跨过零点时end大于24小时，如22:00-06:00的end为30小时
+type Window struct {
+	name     string
+	start    time.Duration
+	end      time.Duration
+	breaks   []Break
+	overtime []Break
+}
+
+// DefaultWindow 默认工作时段 09:00-19:00
+var DefaultWindow = Window{start: 9 * time.Hour, end: 19 * time.Hour}
+
+// NewWindow 创建工作时段，如 NewWindow("09:00", "19:00")，下班早于上班时为跨零点的夜班，如 NewWindow("22:00", "06:00")
+func NewWindow(start string, end string) (Window, error) {
+	s, err := ParseTimeOfDay(start)
+	if err != nil {
+		return Window{}, err
+	}
+	e, err := ParseTimeOfDay(end)
+	if err != nil {
+		return Window{}, err
+	}
+	if e == s {
+		return Window{}, fmt.Errorf("非法工作时段: %s-%s", start, end)
+	}
+	if e < s {
+		e += 24 * time.Hour
+	}
+	return Window{start: s, end: e}, nil
+}
+
+// WithName 为工作时段命名，如“早班”、“夜班”
+func (o Window) WithName(name string) Window {
+	o.name = name
+	return o
+}
+
+// GetName 获取名称（班次名），未命名时为空字符串
+func (o Window) GetName() string { return o.name }
+
+// IsZero 是否为零值，轮班中表示休息日
+func (o Window) IsZero() bool { return o.start == 0 && o.end == 0 }
+
+// IsOvernight 是否跨过零点
+func (o Window) IsOvernight() bool { return o.end > 24*time.Hour }
+
+// GetStart 获取上班时刻（距零点的时长）
+func (o Window) GetStart() time.Duration { return o.start }
+
+// GetEnd 获取下班时刻（距零点的时长）
+func (o Window) GetEnd() time.Duration { return o.end }
+
+// GetDuration 获取时段长度（含休息）
+func (o Window) GetDuration() time.Duration { return o.end - o.start }
+
+// GetBreaks 获取休息（不含加班休息），按时间先后排列
+func (o Window) GetBreaks() []Break { return o.breaks }
+
+// GetOvertimeBreaks 获取加班休息，按时间先后排列
+func (o Window) GetOvertimeBreaks() []Break { return o.overtime }
+
+// WithBreaks 在工作时段中加入休息，休息必须在时段之内且互不重叠，夜班零点后的休息按次日计
+// 加班休息（见 Break.ForOvertime）必须在下班之后，只在工作超出时段时生效
+func (o Window) WithBreaks(breaks ...Break) (Window, error) {
+	all := slices.Clone(o.breaks)
+	overtime := slices.Clone(o.overtime)
+	for _, b := range breaks {
+		if b.start < o.start {
+			b.start, b.end = b.start+24*time.Hour, b.end+24*time.Hour
+		}
+		if b.overtime {
+			overtime = append(overtime, b)
+		} else {
+			all = append(all, b)
+		}
+	}
+	for _, b := range all {
+		if b.start < o.start || b.end > o.end {
+			return Window{}, fmt.Errorf("休息不在工作时段%s之内: %s", o, b)
+		}
+	}
+	for _, b := range overtime {
+		if b.start < o.end {
+			return Window{}, fmt.Errorf("加班休息应在%s下班之后: %s", FormatTimeOfDay(o.end), b)
+		}
+	}
+	if err := sortBreaks(all); err != nil {
+		return Window{}, err
+	}
+	if err := sortBreaks(overtime); err != nil {
+		return Window{}, err
+	}
+	o.breaks, o.overtime = all, overtime
+	if o.GetPaidDuration() == 0 {
+		return Window{}, fmt.Errorf("不计薪的休息占满了工作时段%s", o)
+	}
+	return o, nil
+}
+
+// sortBreaks 按开始时刻排序，并检查休息互不重叠
+func sortBreaks(breaks []Break) error {
+	slices.SortFunc(breaks, func(a, b Break) int { return int(a.start - b.start) })
+	for i := 1; i < len(breaks); i++ {
+		if breaks[i].start < breaks[i-1].end {
+			return fmt.Errorf("休息时间重叠: %s、%s", breaks[i-1], breaks[i])
+		}
+	}
+	return nil
+}
+
+// overtimeBreakAt 获取offset（距零点的时长）所处的加班休息，不在加班休息中返回nil
+func (o Window) overtimeBreakAt(offset time.Duration) *Break {
+	for _, b := range o.overtime {
+		if offset >= b.start && offset < b.end {
+			return &b
+		}
+	}
+	return nil
+}
+
+// GetPaidSegments 获取计薪的工作时段，即扣除不计薪休息后的各段
+func (o Window) GetPaidSegments() []Window {
+	var segments []Window
+	start := o.start
+	for _, b := range o.breaks {
+		if b.paid {
+			continue
+		}
+		if b.start > start {
+			segments = append(segments, Window{start: start, end: b.start})
+		}
+		start = b.end
+	}
+	if o.end > start {
+		segments = append(segments, Window{start: start, end: o.end})
+	}
+	return segments
+}
+
+// GetPaidDuration 获取计薪时长（扣除不计薪的休息）
+func (o Window) GetPaidDuration() time.Duration {
+	var d time.Duration
+	for _, s := range o.GetPaidSegments() {
+		d += s.GetDuration()
+	}
+	return d
+}
+
+// paidBefore 获取到offset（距零点的时长）为止已工作的计薪时长
+func (o Window) paidBefore(offset time.Duration) time.Duration {
+	var d time.Duration
+	for _, s := range o.GetPaidSegments() {
+		d += max(0, min(offset, s.end)-s.start)
+	}
+	return d
+}
+
+// On 获取某天该时段的上下班时刻，day可以是当天任意时刻，按day所在时区计算
+func (o Window) On(day time.Time) (time.Time, time.Time) {
+	midnight := startOfDay(day)
+	return midnight.Add(o.start), midnight.Add(o.end)
+}
+
+// String 字符串表示，如“09:00-19:00”、“22:00-06:00”
+func (o Window) String() string {
+	return FormatTimeOfDay(o.start) + "-" + FormatTimeOfDay(o.end)
+}
+
+// ============ 休息 ============
+
+// Break 工作时段中的休息，如午休；或加班时才有的休息，如加班前的晚饭
+type Break struct {
+	name     string
+	start    time.Duration
+	end      time.Duration
+	paid     bool
+	overtime bool
+}
+
+// LunchBreak 午休 12:00-13:30，不计薪
+var LunchBreak = Break{name: "午休", start: 12 * time.Hour, end: 13*time.Hour + 30*time.Minute}
+
+// NewBreak 创建休息，如 NewBreak("午休", "12:00", "13:30", false)，paid为休息期间是否计薪
+func NewBreak(name string, start string, end string, paid bool) (Break, error) {
+	w, err := NewWindow(start, end)
+	if err != nil {
+		return Break{}, err
+	}
+	return Break{name: name, start: w.start, end: w.end, paid: paid}, nil
+}
+
+// GetName 获取名称
+func (o Break) GetName() string { return o.name }
+
+// GetStart 获取开始时刻（距零点的时长）
+func (o Break) GetStart() time.Duration { return o.start }
+
+// GetEnd 获取结束时刻（距零点的时长）
+func (o Break) GetEnd() time.Duration { return o.end }
+
+// IsPaid 休息期间是否计薪
+func (o Break) IsPaid() bool { return o.paid }
+
+// ForOvertime 改为加班休息：在下班之后，只在工作超出时段时生效，如 17:30 下班后 17:30-18:00 的晚饭
+func (o Break) ForOvertime() Break {
+	o.overtime = true
+	return o
+}
+
+// IsOvertime 是否为加班休息
+func (o Break) IsOvertime() bool { return o.overtime }
+
+// On 获取某天该休息的开始、结束时刻，day为所属工作时段的上班那天
+func (o Break) On(day time.Time) (time.Time, time.Time) {
+	midnight := startOfDay(day)
+	return midnight.Add(o.start), midnight.Add(o.end)
+}
+
+// String 字符串表示，如“午休 12:00-13:30”
+func (o Break) String() string {
+	return o.name + " " + FormatTimeOfDay(o.start) + "-" + FormatTimeOfDay(o.end)
+}
+
+// ============ 钟点 ============
+
+// ParseTimeOfDay 解析“HH:MM”格式的钟点，返回距零点的时长，24:00表示当天结束
+func ParseTimeOfDay(s string) (time.Duration, error) {
+	if len(s) != 5 || s[2] != ':' || !isDigits(s[:2]) || !isDigits(s[3:]) {
+		return 0, fmt.Errorf("非法时刻: %q，格式应为 HH:MM", s)
+	}
+	h := int(s[0]-'0')*10 + int(s[1]-'0')
+	m := int(s[3]-'0')*10 + int(s[4]-'0')
+	if m > 59 || h > 24 || (h == 24 && m > 0) {
+		return 0, fmt.Errorf("非法时刻: %q", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// FormatTimeOfDay 把距零点的时长格式化为“HH:MM”，超过24小时（次日）的按次日钟点
+func FormatTimeOfDay(d time.Duration) string {
+	if d > 24*time.Hour {
+		d -= 24 * time.Hour
+	}
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// isDigits 是否全为数字
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// startOfDay 获取当天零点
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}